@@ -0,0 +1,173 @@
+package itertools
+
+import (
+	"container/heap"
+	"iter"
+	"math"
+	"math/bits"
+	"slices"
+)
+
+// CountedItem is a value with its estimated number of occurrences
+type CountedItem[T any] struct {
+	Value T
+	// Count is an upper bound on the number of occurrences of Value
+	Count int
+	// Error is the maximum amount by which Count may overestimate the true count
+	Error int
+}
+
+// counterHeap is a min-heap of counters ordered by count, tracking each value's position
+type counterHeap[T comparable] struct {
+	items []CountedItem[T]
+	index map[T]int
+}
+
+func (h *counterHeap[T]) Len() int           { return len(h.items) }
+func (h *counterHeap[T]) Less(i, j int) bool { return h.items[i].Count < h.items[j].Count }
+
+func (h *counterHeap[T]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.index[h.items[i].Value] = i
+	h.index[h.items[j].Value] = j
+}
+
+func (h *counterHeap[T]) Push(x any) {
+	item := x.(CountedItem[T])
+	h.index[item.Value] = len(h.items)
+	h.items = append(h.items, item)
+}
+
+func (h *counterHeap[T]) Pop() any {
+	item := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	delete(h.index, item.Value)
+	return item
+}
+
+// HeavyHitters estimates the k most frequent values of s using the Space-Saving algorithm, which holds only k counters regardless of the number of distinct values. Any value occurring more than len/k times is guaranteed to be reported. Results are ordered by descending count
+func HeavyHitters[T comparable](s iter.Seq[T], k int) []CountedItem[T] {
+	h := &counterHeap[T]{index: make(map[T]int, k)}
+
+	for v := range s {
+		if k <= 0 {
+			break
+		}
+
+		if i, ok := h.index[v]; ok {
+			h.items[i].Count++
+			heap.Fix(h, i)
+			continue
+		}
+
+		if h.Len() < k {
+			heap.Push(h, CountedItem[T]{Value: v, Count: 1})
+			continue
+		}
+
+		evicted := heap.Pop(h).(CountedItem[T])
+		heap.Push(h, CountedItem[T]{Value: v, Count: evicted.Count + 1, Error: evicted.Count})
+	}
+
+	out := slices.Clone(h.items)
+	slices.SortStableFunc(out, func(a, b CountedItem[T]) int { return b.Count - a.Count })
+	return out
+}
+
+// mix64 is the splitmix64 finalizer, used to derive independent hash values from a single one
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+type bloomFilter struct {
+	bits   []uint64
+	m      uint64
+	hashes int
+}
+
+// Bloom filter false-positive rates are clamped to this range, since a rate of 0 would need an infinite filter and a rate of 1 or more no filter at all
+const (
+	minFPRate = 1e-9
+	maxFPRate = 0.5
+)
+
+func newBloomFilter(expectedN int, fpRate float64) *bloomFilter {
+	if !(fpRate >= minFPRate) {
+		fpRate = minFPRate
+	}
+	fpRate = min(fpRate, maxFPRate)
+
+	n := float64(max(expectedN, 1))
+	m := max(uint64(math.Ceil(-n*math.Log(fpRate)/(math.Ln2*math.Ln2))), 64)
+	k := max(int(math.Round(float64(m)/n*math.Ln2)), 1)
+	return &bloomFilter{bits: make([]uint64, (m+63)/64), m: m, hashes: k}
+}
+
+// add inserts h into the filter, reporting whether it may already have been present
+func (b *bloomFilter) add(h uint64) bool {
+	h1, h2 := h, mix64(h)|1
+	present := true
+	for i := range b.hashes {
+		bit := (h1 + uint64(i)*h2) % b.m
+		word, mask := bit/64, uint64(1)<<(bit%64)
+		if b.bits[word]&mask == 0 {
+			present = false
+			b.bits[word] |= mask
+		}
+	}
+	return present
+}
+
+// UniqueApprox yields the first occurrence of each value of s using a Bloom filter sized for expectedN distinct values, so memory stays constant. Roughly fpRate of first occurrences may be wrongly dropped as duplicates, but a duplicate is never yielded. fpRate is clamped to [1e-9, 0.5]
+func UniqueApprox[T any](s iter.Seq[T], hash func(T) uint64, expectedN int, fpRate float64) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := newBloomFilter(expectedN, fpRate)
+		for v := range s {
+			if seen.add(hash(v)) {
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// hllPrecision is the number of hash bits used to select a HyperLogLog register, giving a standard error of about 0.8%
+const hllPrecision = 14
+
+// EstimateDistinct estimates the number of distinct values in s using HyperLogLog, needing only a few kilobytes however many values there are
+func EstimateDistinct[T any](s iter.Seq[T], hash func(T) uint64) uint64 {
+	const m = 1 << hllPrecision
+	var registers [m]uint8
+
+	for v := range s {
+		h := mix64(hash(v))
+		idx := h >> (64 - hllPrecision)
+		rank := uint8(min(bits.LeadingZeros64(h<<hllPrecision), 64-hllPrecision) + 1)
+		if rank > registers[idx] {
+			registers[idx] = rank
+		}
+	}
+
+	var sum float64
+	var zeros int
+	for _, r := range registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	alpha := 0.7213 / (1 + 1.079/m)
+	estimate := alpha * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(float64(m)/float64(zeros))
+	}
+	return uint64(math.Round(estimate))
+}
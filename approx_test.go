@@ -0,0 +1,60 @@
+package itertools
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeavyHitters(t *testing.T) {
+	got := HeavyHitters(NewSeq([]byte("aabacadaaebbf")...), 3)
+	assert.Len(t, got, 3)
+	assert.Equal(t, CountedItem[byte]{'a', 6, 0}, got[0])
+	assert.Equal(t, byte('b'), got[1].Value)
+	assert.GreaterOrEqual(t, got[1].Count, 3)
+
+	exact := HeavyHitters(NewSeq(1, 2, 2, 3, 3, 3), 5)
+	assert.Equal(t, []CountedItem[int]{{3, 3, 0}, {2, 2, 0}, {1, 1, 0}}, exact)
+
+	assert.Empty(t, HeavyHitters(NewSeq(1, 2), 0))
+}
+
+func TestUniqueApprox(t *testing.T) {
+	hash := func(x int) uint64 { return mix64(uint64(x)) }
+
+	assertSequenceMatch(t,
+		UniqueApprox(NewSeq(1, 2, 1, 3, 2, 4, 1), hash, 100, 0.01),
+		[]int{1, 2, 3, 4},
+	)
+
+	var n int
+	for range UniqueApprox(Take(Count(), 10000), hash, 10000, 0.01) {
+		n++
+	}
+	assert.Greater(t, n, 9800)
+
+	for _, fpRate := range []float64{0, -1, math.NaN()} {
+		assertSequenceMatch(t,
+			UniqueApprox(NewSeq(1, 2, 1, 3, 2, 4, 1), hash, 100, fpRate),
+			[]int{1, 2, 3, 4},
+		)
+	}
+	for _, fpRate := range []float64{1, 2} {
+		n = 0
+		for range UniqueApprox(Take(Count(), 1000), hash, 1000, fpRate) {
+			n++
+		}
+		assert.Greater(t, n, 500)
+	}
+}
+
+func TestEstimateDistinct(t *testing.T) {
+	hash := func(x int) uint64 { return uint64(x) }
+
+	assert.Equal(t, uint64(0), EstimateDistinct(NewSeq[int](), hash))
+	assert.Equal(t, uint64(3), EstimateDistinct(NewSeq(1, 2, 3, 2, 1), hash))
+
+	got := EstimateDistinct(Map(func(x int) int { return x % 50000 }, Take(Count(), 200000)), hash)
+	assert.InEpsilon(t, 50000, got, 0.03)
+}
@@ -0,0 +1,23 @@
+package itertools
+
+import "iter"
+
+// Add yields the element-wise sums of a and b, stopping when either is exhausted
+func Add[T Number](a, b iter.Seq[T]) iter.Seq[T] {
+	return ZipWith(func(x, y T) T { return x + y }, a, b)
+}
+
+// Sub yields the element-wise differences of a and b, stopping when either is exhausted
+func Sub[T Number](a, b iter.Seq[T]) iter.Seq[T] {
+	return ZipWith(func(x, y T) T { return x - y }, a, b)
+}
+
+// Mul yields the element-wise products of a and b, stopping when either is exhausted
+func Mul[T Number](a, b iter.Seq[T]) iter.Seq[T] {
+	return ZipWith(func(x, y T) T { return x * y }, a, b)
+}
+
+// Div yields the element-wise quotients of a and b, stopping when either is exhausted. As with the / operator, integer division by zero panics
+func Div[T Number](a, b iter.Seq[T]) iter.Seq[T] {
+	return ZipWith(func(x, y T) T { return x / y }, a, b)
+}
@@ -0,0 +1,19 @@
+package itertools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArithmetic(t *testing.T) {
+	a, b := NewSeq(6, 8, 10), NewSeq(3, 2, 5, 7)
+	assertSequenceMatch(t, Add(a, b), []int{9, 10, 15})
+	assertSequenceMatch(t, Sub(a, b), []int{3, 6, 5})
+	assertSequenceMatch(t, Mul(a, b), []int{18, 16, 50})
+	assertSequenceMatch(t, Div(a, b), []int{2, 4, 2})
+
+	assertSequenceMatch(t, Div(NewSeq(1.0, 3.0), NewSeq(4.0, 2.0)), []float64{0.25, 1.5})
+	assertSequenceMatch(t, Take(Add(Count(), Count()), 3), []int{0, 2, 4})
+	assert.Panics(t, func() { toSlice(Div(NewSeq(1), NewSeq(0))) })
+}
@@ -0,0 +1,48 @@
+// Package benchdata generates reproducible input data for benchmarking sequence pipelines
+package benchdata
+
+import "math/rand/v2"
+
+// Seed is the fixed seed behind every generator, so the same calls produce the same data on every machine
+const Seed = 0x17e27001
+
+const alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// NewRand returns a random source seeded with [Seed]
+func NewRand() *rand.Rand {
+	return rand.New(rand.NewPCG(Seed, Seed))
+}
+
+// MakeInts returns n pseudo-random non-negative ints
+func MakeInts(n int) []int {
+	r := NewRand()
+	out := make([]int, n)
+	for i := range out {
+		out[i] = r.Int()
+	}
+	return out
+}
+
+// MakeStrings returns n pseudo-random alphanumeric strings of the given length
+func MakeStrings(n, length int) []string {
+	r := NewRand()
+	out := make([]string, n)
+	buf := make([]byte, length)
+	for i := range out {
+		for j := range buf {
+			buf[j] = alphabet[r.IntN(len(alphabet))]
+		}
+		out[i] = string(buf)
+	}
+	return out
+}
+
+// MakeStructs returns n values built by gen, which is handed a random source seeded with [Seed]
+func MakeStructs[T any](n int, gen func(*rand.Rand) T) []T {
+	r := NewRand()
+	out := make([]T, n)
+	for i := range out {
+		out[i] = gen(r)
+	}
+	return out
+}
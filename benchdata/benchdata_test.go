@@ -0,0 +1,34 @@
+package benchdata
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeInts(t *testing.T) {
+	ints := MakeInts(100)
+	assert.Len(t, ints, 100)
+	assert.Equal(t, ints, MakeInts(100))
+	assert.Equal(t, ints[:10], MakeInts(10))
+	assert.NotEqual(t, ints[0], ints[1])
+}
+
+func TestMakeStrings(t *testing.T) {
+	strs := MakeStrings(50, 8)
+	assert.Len(t, strs, 50)
+	assert.Equal(t, strs, MakeStrings(50, 8))
+	for _, s := range strs {
+		assert.Len(t, s, 8)
+	}
+}
+
+func TestMakeStructs(t *testing.T) {
+	type point struct{ X, Y float64 }
+	gen := func(r *rand.Rand) point { return point{r.Float64(), r.Float64()} }
+
+	points := MakeStructs(20, gen)
+	assert.Len(t, points, 20)
+	assert.Equal(t, points, MakeStructs(20, gen))
+}
@@ -0,0 +1,55 @@
+package itertools
+
+import (
+	"context"
+	"iter"
+)
+
+// FromChan yields the values received from ch until it is closed
+func FromChan[T any](ch <-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ToChan sends the values of s on a channel with buffer size buf from a new goroutine, closing the channel once s is exhausted. If ctx is cancelled the goroutine stops sending, stops s and closes the channel, so a receiver that gives up early must cancel ctx to avoid leaking it
+func ToChan[T any](ctx context.Context, s iter.Seq[T], buf int) <-chan T {
+	ch := make(chan T, buf)
+	go func() {
+		defer close(ch)
+		for v := range s {
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case ch <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// Buffered reads up to n values of s ahead on a background goroutine, overlapping slow production with slow consumption. When the consumer stops early, the goroutine is stopped and waited for before the loop returns
+func Buffered[T any](s iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := ToChan(ctx, s, n)
+		defer func() {
+			cancel()
+			for range ch {
+			}
+		}()
+
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
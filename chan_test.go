@@ -0,0 +1,74 @@
+package itertools
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromChan(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+	assertSequenceMatch(t, FromChan(ch), []int{1, 2, 3})
+
+	ch = make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	assertSequenceMatch(t, Take(FromChan(ch), 1), []int{1})
+	assert.Equal(t, 2, <-ch)
+}
+
+func TestToChan(t *testing.T) {
+	assertSequenceMatch(t, FromChan(ToChan(context.Background(), NewSeq(1, 2, 3), 0)), []int{1, 2, 3})
+	assertSequenceMatch(t, FromChan(ToChan(context.Background(), NewSeq(1, 2, 3), 5)), []int{1, 2, 3})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	src := OnClose(Count(), func() { close(stopped) })
+
+	ch := ToChan(ctx, src, 0)
+	assert.Equal(t, 0, <-ch)
+	assert.Equal(t, 1, <-ch)
+	cancel()
+	<-stopped
+
+	// at most one value was already on its way before the cancellation was seen
+	var rest []int
+	for v := range ch {
+		rest = append(rest, v)
+	}
+	assert.LessOrEqual(t, len(rest), 1)
+}
+
+func TestBuffered(t *testing.T) {
+	assertSequenceMatch(t, Buffered(NewSeq(1, 2, 3), 2), []int{1, 2, 3})
+	assertSequenceMatch(t, Buffered(NewSeq(1, 2, 3), 0), []int{1, 2, 3})
+
+	var produced atomic.Int32
+	var stopped bool
+	src := OnClose(func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			produced.Add(1)
+			if !yield(i) {
+				return
+			}
+		}
+	}, func() { stopped = true })
+
+	assertSequenceMatch(t, Take(Buffered(src, 4), 3), []int{0, 1, 2})
+	assert.True(t, stopped)
+	assert.LessOrEqual(t, produced.Load(), int32(3+4+1))
+
+	// values are read ahead while the consumer is busy
+	produced.Store(0)
+	for range Buffered(src, 4) {
+		assert.Eventually(t, func() bool { return produced.Load() >= 5 }, time.Second, time.Millisecond)
+		break
+	}
+}
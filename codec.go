@@ -0,0 +1,131 @@
+package itertools
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+)
+
+// Codec serializes values of type T one at a time, so that features writing sequences to disk share a single definition of how elements are stored. Decode reads exactly one value, returning [io.EOF] when r holds no further values
+type Codec[T any] interface {
+	Encode(w io.Writer, v T) error
+	Decode(r io.Reader) (T, error)
+}
+
+// JSONCodec is a [Codec] storing each value as a line of JSON
+type JSONCodec[T any] struct{}
+
+// Encode writes v as JSON followed by a newline
+func (JSONCodec[T]) Encode(w io.Writer, v T) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+// Decode reads a single line of JSON without consuming anything past it
+func (JSONCodec[T]) Decode(r io.Reader) (T, error) {
+	var v T
+	line, err := readLine(r)
+	if err != nil {
+		return v, err
+	}
+	return v, json.Unmarshal(line, &v)
+}
+
+// readLine reads up to and including the next newline a byte at a time, so r is left positioned at the following value
+func readLine(r io.Reader) ([]byte, error) {
+	br := byteReader(r)
+	var line []byte
+	for {
+		b, err := br.ReadByte()
+		if errors.Is(err, io.EOF) && len(line) > 0 {
+			return line, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if b == '\n' {
+			return line, nil
+		}
+		line = append(line, b)
+	}
+}
+
+// GobCodec is a [Codec] storing each value as a length-prefixed gob message
+type GobCodec[T any] struct{}
+
+// Encode writes v as a self-contained gob message preceded by its length
+func (GobCodec[T]) Encode(w io.Writer, v T) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return err
+	}
+	if _, err := w.Write(binary.AppendUvarint(nil, uint64(buf.Len()))); err != nil {
+		return err
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// Decode reads a single length-prefixed gob message without consuming anything past it
+func (GobCodec[T]) Decode(r io.Reader) (T, error) {
+	var v T
+	n, err := binary.ReadUvarint(byteReader(r))
+	if err != nil {
+		return v, err
+	}
+
+	msg := make([]byte, n)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return v, err
+	}
+	return v, gob.NewDecoder(bytes.NewReader(msg)).Decode(&v)
+}
+
+// singleByteReader reads one byte at a time from a reader that cannot do so itself
+type singleByteReader struct {
+	r   io.Reader
+	buf [1]byte
+}
+
+func (b *singleByteReader) ReadByte() (byte, error) {
+	_, err := io.ReadFull(b.r, b.buf[:])
+	return b.buf[0], err
+}
+
+func byteReader(r io.Reader) io.ByteReader {
+	if br, ok := r.(io.ByteReader); ok {
+		return br
+	}
+	return &singleByteReader{r: r}
+}
+
+// EncodeAll writes every value of s to w using c, stopping at the first error
+func EncodeAll[T any](w io.Writer, c Codec[T], s iter.Seq[T]) error {
+	for v := range s {
+		if err := c.Encode(w, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeAll yields the values read from r using c until r is exhausted, ending with a terminal error if decoding fails. Pass a buffered reader such as a [bufio.Reader] to avoid reading a byte at a time
+func DecodeAll[T any](r io.Reader, c Codec[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			v, err := c.Decode(r)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if !yield(v, err) || err != nil {
+				return
+			}
+		}
+	}
+}
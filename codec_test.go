@@ -0,0 +1,51 @@
+package itertools
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type codecRecord struct {
+	Name  string
+	Score int
+}
+
+func TestCodecs(t *testing.T) {
+	records := []codecRecord{{"ann", 3}, {"bob", -1}, {"", 0}}
+	codecs := map[string]Codec[codecRecord]{
+		"json": JSONCodec[codecRecord]{},
+		"gob":  GobCodec[codecRecord]{},
+	}
+
+	for name, c := range codecs {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			assert.NoError(t, EncodeAll(&buf, c, FromSlice(records)))
+			encoded := buf.Bytes()
+
+			got, errs := CollectErrs(DecodeAll(bytes.NewReader(encoded), c), 0)
+			assert.Empty(t, errs)
+			assert.Equal(t, records, got)
+
+			// an unbuffered reader is left positioned at the next value
+			r := io.MultiReader(bytes.NewReader(encoded))
+			first, err := c.Decode(r)
+			assert.NoError(t, err)
+			assert.Equal(t, records[0], first)
+			got, errs = CollectErrs(DecodeAll(bufio.NewReader(r), c), 0)
+			assert.Empty(t, errs)
+			assert.Equal(t, records[1:], got)
+
+			_, err = c.Decode(bytes.NewReader(nil))
+			assert.ErrorIs(t, err, io.EOF)
+
+			got, errs = CollectErrs(DecodeAll(bytes.NewReader(encoded[:len(encoded)-2]), c), 0)
+			assert.Len(t, errs, 1)
+			assert.Equal(t, records[:2], got)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package itertools
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Number is satisfied by the built-in integer and floating-point types
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Reduce folds the values of s into a single result, starting from initial and combining with f
+func Reduce[T any, A any](s iter.Seq[T], f func(A, T) A, initial A) A {
+	acc := initial
+	for v := range s {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// ReduceFirst folds the values of s using the first value as the initial result, reporting false if s is empty
+func ReduceFirst[T any](s iter.Seq[T], f func(T, T) T) (T, bool) {
+	var acc T
+	var ok bool
+	for v := range s {
+		if !ok {
+			acc, ok = v, true
+			continue
+		}
+		acc = f(acc, v)
+	}
+	return acc, ok
+}
+
+// Sum adds up the values of s, reporting false if s is empty
+func Sum[T Number](s iter.Seq[T]) (T, bool) {
+	return ReduceFirst(s, func(a, b T) T { return a + b })
+}
+
+// Min returns the smallest value of s, reporting false if s is empty
+func Min[T cmp.Ordered](s iter.Seq[T]) (T, bool) {
+	return ReduceFirst(s, func(a, b T) T { return min(a, b) })
+}
+
+// Max returns the largest value of s, reporting false if s is empty
+func Max[T cmp.Ordered](s iter.Seq[T]) (T, bool) {
+	return ReduceFirst(s, func(a, b T) T { return max(a, b) })
+}
+
+// MinBy returns the first value of s with the smallest key, reporting false if s is empty
+func MinBy[T any, K cmp.Ordered](s iter.Seq[T], key func(T) K) (T, bool) {
+	return extremeBy(s, key, func(candidate, best K) bool { return candidate < best })
+}
+
+// MaxBy returns the first value of s with the largest key, reporting false if s is empty
+func MaxBy[T any, K cmp.Ordered](s iter.Seq[T], key func(T) K) (T, bool) {
+	return extremeBy(s, key, func(candidate, best K) bool { return candidate > best })
+}
+
+func extremeBy[T any, K cmp.Ordered](s iter.Seq[T], key func(T) K, better func(candidate, best K) bool) (T, bool) {
+	var best T
+	var bestKey K
+	var ok bool
+	for v := range s {
+		k := key(v)
+		if !ok || better(k, bestKey) {
+			best, bestKey, ok = v, k, true
+		}
+	}
+	return best, ok
+}
+
+// All reports whether pred holds for every value of s, stopping at the first that fails. It is true for an empty sequence
+func All[T any](pred func(T) bool, s iter.Seq[T]) bool {
+	for v := range s {
+		if !pred(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Any reports whether pred holds for some value of s, stopping at the first that does
+func Any[T any](pred func(T) bool, s iter.Seq[T]) bool {
+	for v := range s {
+		if pred(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// None reports whether pred holds for no value of s, stopping at the first that does
+func None[T any](pred func(T) bool, s iter.Seq[T]) bool {
+	return !Any(pred, s)
+}
+
+// Contains reports whether v appears in s, stopping as soon as it is found
+func Contains[T comparable](s iter.Seq[T], v T) bool {
+	return Any(func(x T) bool { return x == v }, s)
+}
+
+func sizeHint(size []int) int {
+	if len(size) > 0 {
+		return size[0]
+	}
+	return 0
+}
+
+// ToSlice collects the values of s into a slice, preallocating room for size values if given
+func ToSlice[T any](s iter.Seq[T], size ...int) []T {
+	out := make([]T, 0, sizeHint(size))
+	for v := range s {
+		out = append(out, v)
+	}
+	return out
+}
+
+// ToMap collects the pairs of s into a map, with later values replacing earlier ones for the same key. size, if given, presizes the map
+func ToMap[K comparable, V any](s iter.Seq2[K, V], size ...int) map[K]V {
+	out := make(map[K]V, sizeHint(size))
+	for k, v := range s {
+		out[k] = v
+	}
+	return out
+}
+
+// ToSet collects the distinct values of s into a set, presized for size values if given
+func ToSet[T comparable](s iter.Seq[T], size ...int) map[T]struct{} {
+	out := make(map[T]struct{}, sizeHint(size))
+	for v := range s {
+		out[v] = struct{}{}
+	}
+	return out
+}
@@ -0,0 +1,110 @@
+package itertools
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReduce(t *testing.T) {
+	assert.Equal(t, 10, Reduce(NewSeq(1, 2, 3, 4), func(acc, x int) int { return acc + x }, 0))
+	assert.Equal(t, "x123", Reduce(NewSeq(1, 2, 3), func(acc string, x int) string { return acc + strconv.Itoa(x) }, "x"))
+	assert.Equal(t, 7, Reduce(NewSeq[int](), func(acc, x int) int { return acc + x }, 7))
+}
+
+func TestReduceFirst(t *testing.T) {
+	maxOf := func(a, b int) int { return max(a, b) }
+
+	got, ok := ReduceFirst(NewSeq(3, 9, 2), maxOf)
+	assert.True(t, ok)
+	assert.Equal(t, 9, got)
+
+	_, ok = ReduceFirst(NewSeq[int](), maxOf)
+	assert.False(t, ok)
+}
+
+func TestSum(t *testing.T) {
+	total, ok := Sum(NewSeq(1, 2, 3))
+	assert.True(t, ok)
+	assert.Equal(t, 6, total)
+
+	ftotal, ok := Sum(NewSeq(0.5, 0.25))
+	assert.True(t, ok)
+	assert.Equal(t, 0.75, ftotal)
+
+	_, ok = Sum(NewSeq[uint8]())
+	assert.False(t, ok)
+}
+
+func TestMinMax(t *testing.T) {
+	lo, ok := Min(NewSeq(4, 2, 8, 2))
+	assert.True(t, ok)
+	assert.Equal(t, 2, lo)
+
+	hi, ok := Max(NewSeq("pear", "apple", "zucchini"))
+	assert.True(t, ok)
+	assert.Equal(t, "zucchini", hi)
+
+	_, ok = Min(NewSeq[int]())
+	assert.False(t, ok)
+	_, ok = Max(NewSeq[int]())
+	assert.False(t, ok)
+}
+
+func TestMinByMaxBy(t *testing.T) {
+	words := NewSeq("bb", "a", "ccc", "d", "eee")
+	length := func(s string) int { return len(s) }
+
+	shortest, ok := MinBy(words, length)
+	assert.True(t, ok)
+	assert.Equal(t, "a", shortest)
+
+	longest, ok := MaxBy(words, length)
+	assert.True(t, ok)
+	assert.Equal(t, "ccc", longest)
+
+	_, ok = MaxBy(NewSeq[string](), length)
+	assert.False(t, ok)
+}
+
+func TestAll(t *testing.T) {
+	assert.True(t, All(isEven, NewSeq(2, 4, 6)))
+	assert.False(t, All(isEven, Count()))
+	assert.True(t, All(isEven, NewSeq[int]()))
+}
+
+func TestAny(t *testing.T) {
+	assert.True(t, Any(func(x int) bool { return x > 10 }, Count()))
+	assert.False(t, Any(isEven, NewSeq(1, 3)))
+	assert.False(t, Any(isEven, NewSeq[int]()))
+}
+
+func TestNone(t *testing.T) {
+	assert.True(t, None(isEven, NewSeq(1, 3)))
+	assert.False(t, None(isEven, Count()))
+}
+
+func TestContains(t *testing.T) {
+	assert.True(t, Contains(Count(), 42))
+	assert.False(t, Contains(NewSeq("a", "b"), "c"))
+}
+
+func TestToSlice(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3}, ToSlice(NewSeq(1, 2, 3)))
+	assert.Equal(t, []int{}, ToSlice(NewSeq[int]()))
+
+	withHint := ToSlice(NewSeq(1, 2), 10)
+	assert.Equal(t, []int{1, 2}, withHint)
+	assert.Equal(t, 10, cap(withHint))
+}
+
+func TestToMap(t *testing.T) {
+	assert.Equal(t, map[int]string{0: "a", 1: "b"}, ToMap(Enumerate(NewSeq("a", "b")), 2))
+	assert.Equal(t, map[string]int{"a": 0, "b": 1}, ToMap(Map2(func(i int, s string) (string, int) { return s, i }, Enumerate(NewSeq("a", "b")))))
+}
+
+func TestToSet(t *testing.T) {
+	assert.Equal(t, map[string]struct{}{"a": {}, "b": {}}, ToSet(NewSeq("a", "b", "a")))
+	assert.Empty(t, ToSet(NewSeq[int](), 4))
+}
@@ -0,0 +1,47 @@
+package itertools
+
+import "iter"
+
+// ToColumns collects the rows of s into column vectors, where column i holds the i-th value of every row. Ragged rows are padded with zero values, so every column has one value per row and the j-th value of each column comes from the j-th row
+func ToColumns[T any](s iter.Seq[[]T]) [][]T {
+	var cols [][]T
+	var n int
+	for row := range s {
+		cols = appendColumns(cols, n, row)
+		n++
+	}
+	return cols
+}
+
+// Transpose groups the rows of s into blocks of width rows and yields the columns of each block in turn, so each yielded slice holds one value per row of its block, at most width. It is the streaming counterpart of [ToColumns] and pads ragged rows with zero values the same way
+func Transpose[T any](s iter.Seq[[]T], width int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		for block := range Batched(s, width) {
+			var cols [][]T
+			for n, row := range block {
+				cols = appendColumns(cols, n, row)
+			}
+			for _, col := range cols {
+				if !yield(col) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// appendColumns appends row as the n-th row of cols, adding columns as needed and padding with zero values so every column stays n+1 long
+func appendColumns[T any](cols [][]T, n int, row []T) [][]T {
+	for len(cols) < len(row) {
+		cols = append(cols, make([]T, n))
+	}
+	var zero T
+	for i := range cols {
+		if i < len(row) {
+			cols[i] = append(cols[i], row[i])
+		} else {
+			cols[i] = append(cols[i], zero)
+		}
+	}
+	return cols
+}
@@ -0,0 +1,28 @@
+package itertools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToColumns(t *testing.T) {
+	rows := NewSeq([]int{1, 2, 3}, []int{4, 5, 6})
+	assert.Equal(t, [][]int{{1, 4}, {2, 5}, {3, 6}}, ToColumns(rows))
+
+	assert.Equal(t, [][]int{{1, 3, 4}, {2, 0, 5}}, ToColumns(NewSeq([]int{1, 2}, []int{3}, []int{4, 5})))
+	assert.Equal(t, [][]int{{1, 2, 4}, {0, 3, 5}, {0, 0, 6}}, ToColumns(NewSeq([]int{1}, []int{2, 3}, []int{4, 5, 6})))
+	assert.Empty(t, ToColumns(NewSeq[[]int]()))
+}
+
+func TestTranspose(t *testing.T) {
+	rows := NewSeq([]int{1, 2}, []int{3, 4}, []int{5, 6})
+	assertSequenceMatch(t, Transpose(rows, 2), [][]int{{1, 3}, {2, 4}, {5}, {6}})
+	assertSequenceMatch(t, Transpose(rows, 3), [][]int{{1, 3, 5}, {2, 4, 6}})
+
+	ragged := NewSeq([]int{1}, []int{2, 3}, []int{4})
+	assertSequenceMatch(t, Transpose(ragged, 2), [][]int{{1, 2}, {0, 3}, {4}})
+
+	infinite := Map(func(i int) []int { return []int{i, -i} }, Count())
+	assertSequenceMatch(t, Take(Transpose(infinite, 3), 3), [][]int{{0, 1, 2}, {0, -1, -2}, {3, 4, 5}})
+}
@@ -0,0 +1,45 @@
+package itertools
+
+import (
+	"context"
+	"iter"
+)
+
+// DrainUntil collects values from s until ctx is done or s is exhausted, returning them along with a sequence over the untouched remainder of s. The remainder may only be ranged over once and should be ranged over (even if just to break) to release the underlying iterator
+func DrainUntil[T any](ctx context.Context, s iter.Seq[T]) ([]T, iter.Seq[T]) {
+	next, stop := iter.Pull(s)
+
+	out := make([]T, 0)
+	for ctx.Err() == nil {
+		v, ok := next()
+		if !ok {
+			stop()
+			return out, func(func(T) bool) {}
+		}
+		out = append(out, v)
+	}
+
+	return out, func(yield func(T) bool) {
+		defer stop()
+		for {
+			v, ok := next()
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// WithContext yields the values of s until ctx is done, letting long or infinite sequences be aborted from outside. The context is checked before each value is pulled from s, so a source blocked inside its own iteration is not interrupted
+func WithContext[T any](ctx context.Context, s iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if ctx.Err() != nil {
+			return
+		}
+		for v := range s {
+			if !yield(v) || ctx.Err() != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package itertools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrainUntil(t *testing.T) {
+	got, rest := DrainUntil(context.Background(), NewSeq(1, 2, 3))
+	assert.Equal(t, []int{1, 2, 3}, got)
+	assertSequenceMatch(t, rest, []int{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	src := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if i == 3 {
+				cancel()
+			}
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	got, rest = DrainUntil(ctx, src)
+	assert.Equal(t, []int{0, 1, 2, 3}, got)
+	assertSequenceMatch(t, Take(rest, 3), []int{4, 5, 6})
+}
+
+func TestWithContext(t *testing.T) {
+	assertSequenceMatch(t, WithContext(context.Background(), NewSeq(1, 2, 3)), []int{1, 2, 3})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var got []int
+	for v := range WithContext(ctx, Count()) {
+		got = append(got, v)
+		if v == 2 {
+			cancel()
+		}
+	}
+	assert.Equal(t, []int{0, 1, 2}, got)
+
+	assertSequenceMatch(t, WithContext(ctx, Count()), []int{})
+}
@@ -0,0 +1,141 @@
+package itertools
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"strconv"
+)
+
+// CSVRecords yields the records read from r. Malformed records are reported as [*csv.ParseError] and reading continues with the next one, while any other error ends the sequence
+func CSVRecords(r *csv.Reader) iter.Seq2[[]string, error] {
+	return func(yield func([]string, error) bool) {
+		for {
+			record, err := r.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if !yield(record, err) {
+				return
+			}
+
+			var parseErr *csv.ParseError
+			if err != nil && !errors.As(err, &parseErr) {
+				return
+			}
+		}
+	}
+}
+
+// CSVInto decodes the records read from r into values of the struct type T, matching the header in the first record against the `csv` tags of T's fields, or their names when untagged. Fields tagged "-" and columns without a matching field are ignored. Fields may be strings, booleans, integers, floats or implement [encoding.TextUnmarshaler]
+func CSVInto[T any](r *csv.Reader) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		typ := reflect.TypeOf(zero)
+		if typ == nil || typ.Kind() != reflect.Struct {
+			yield(zero, fmt.Errorf("itertools: CSVInto requires a struct type, not %v", typ))
+			return
+		}
+
+		fields := make(map[string]int)
+		for i := range typ.NumField() {
+			f := typ.Field(i)
+			name := f.Tag.Get("csv")
+			if !f.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			fields[name] = i
+		}
+
+		// columns maps each column to its field index, or -1 if it has none
+		var columns []int
+		for record, err := range CSVRecords(r) {
+			if err != nil {
+				if !yield(zero, err) {
+					return
+				}
+				continue
+			}
+
+			if columns == nil {
+				columns = make([]int, len(record))
+				for i, name := range record {
+					field, ok := fields[name]
+					if !ok {
+						field = -1
+					}
+					columns[i] = field
+				}
+				continue
+			}
+
+			var v T
+			err = decodeCSVRecord(reflect.ValueOf(&v).Elem(), columns, record)
+			if err != nil {
+				line, _ := r.FieldPos(0)
+				err = fmt.Errorf("itertools: csv line %d: %w", line, err)
+			}
+			if !yield(v, err) {
+				return
+			}
+		}
+	}
+}
+
+func decodeCSVRecord(v reflect.Value, columns []int, record []string) error {
+	for i, cell := range record {
+		if i >= len(columns) || columns[i] < 0 {
+			continue
+		}
+		field := v.Field(columns[i])
+		if err := setCSVField(field, cell); err != nil {
+			return fmt.Errorf("field %s: %w", v.Type().Field(columns[i]).Name, err)
+		}
+	}
+	return nil
+}
+
+func setCSVField(field reflect.Value, cell string) error {
+	if u, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(cell))
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(cell)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(cell)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(cell, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(cell, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(cell, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %v", field.Type())
+	}
+	return nil
+}
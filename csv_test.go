@@ -0,0 +1,63 @@
+package itertools
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVRecords(t *testing.T) {
+	r := csv.NewReader(strings.NewReader("a,b\n1,2\n3,\"4\n"))
+	records, errs := CollectErrs(CSVRecords(r), 0)
+	assert.Equal(t, [][]string{{"a", "b"}, {"1", "2"}}, records)
+	assert.Len(t, errs, 1)
+
+	r = csv.NewReader(strings.NewReader("a,b\n1\n3,4\n"))
+	records, errs = CollectErrs(CSVRecords(r), 0)
+	assert.Equal(t, [][]string{{"a", "b"}, {"3", "4"}}, records)
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], csv.ErrFieldCount)
+
+	r = csv.NewReader(strings.NewReader("1\n2\n3\n"))
+	records, _ = CollectErrs(Take2(CSVRecords(r), 2), 0)
+	assert.Equal(t, [][]string{{"1"}, {"2"}}, records)
+}
+
+func TestCSVInto(t *testing.T) {
+	type trade struct {
+		Symbol string    `csv:"symbol"`
+		Price  float64   `csv:"price"`
+		Qty    uint16    `csv:"qty"`
+		Short  bool      `csv:"short"`
+		At     time.Time `csv:"at"`
+		Note   string
+		Secret string `csv:"-"`
+	}
+
+	input := "" +
+		"symbol,qty,price,short,at,Note,Secret,extra\n" +
+		"ABC,10,1.5,false,2024-01-02T03:04:05Z,first,x,y\n" +
+		"XYZ,oops,2,true,2024-01-02T03:04:05Z,,x,y\n" +
+		"DEF,7,3.25,true,2024-01-03T00:00:00Z,third,x,y\n"
+
+	trades, errs := CollectErrs(CSVInto[trade](csv.NewReader(strings.NewReader(input))), 0)
+	assert.Equal(t, []trade{
+		{Symbol: "ABC", Price: 1.5, Qty: 10, At: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Note: "first"},
+		{Symbol: "DEF", Price: 3.25, Qty: 7, Short: true, At: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), Note: "third"},
+	}, trades)
+	assert.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "csv line 3: field Qty")
+
+	_, errs = CollectErrs(CSVInto[int](csv.NewReader(strings.NewReader("a\n1\n"))), 0)
+	assert.Len(t, errs, 1)
+
+	type unsupported struct {
+		Tags []string `csv:"tags"`
+	}
+	_, errs = CollectErrs(CSVInto[unsupported](csv.NewReader(strings.NewReader("tags\na\n"))), 0)
+	assert.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "unsupported type []string")
+}
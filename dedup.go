@@ -0,0 +1,142 @@
+package itertools
+
+import (
+	"container/list"
+	"iter"
+	"time"
+)
+
+// Distinct yields each value of s the first time it is seen, like Python's unique_everseen. Every distinct value is remembered, so memory grows with the number of distinct values; see [DedupWithin] for a bounded alternative
+func Distinct[T comparable](s iter.Seq[T]) iter.Seq[T] {
+	return DistinctBy(func(v T) T { return v }, s)
+}
+
+// DistinctBy is [Distinct] comparing values by key, yielding the first value seen for each key
+func DistinctBy[T any, K comparable](key func(T) K, s iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := make(map[K]struct{})
+		for v := range s {
+			k := key(v)
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// DedupConsecutive collapses each run of equal adjacent values of s to its first value, like more-itertools' unique_justseen. Only the previous value is remembered, so memory is constant
+func DedupConsecutive[T comparable](s iter.Seq[T]) iter.Seq[T] {
+	return DedupConsecutiveBy(func(v T) T { return v }, s)
+}
+
+// DedupConsecutiveBy is [DedupConsecutive] comparing values by key
+func DedupConsecutiveBy[T any, K comparable](key func(T) K, s iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var last K
+		var started bool
+		for v := range s {
+			k := key(v)
+			if started && k == last {
+				continue
+			}
+			last, started = k, true
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// DedupWithin yields the values of s, suppressing any value already seen among the last window distinct values. Seen values are tracked in an LRU set, so memory is bounded by window and repeated sightings keep a value fresh
+func DedupWithin[T comparable](s iter.Seq[T], window int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		recent := list.New()
+		seen := make(map[T]*list.Element, window)
+
+		for v := range s {
+			if e, ok := seen[v]; ok {
+				recent.MoveToBack(e)
+				continue
+			}
+
+			if recent.Len() >= window && recent.Len() > 0 {
+				delete(seen, recent.Remove(recent.Front()).(T))
+			}
+			seen[v] = recent.PushBack(v)
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+type sighting[K any] struct {
+	key K
+	at  time.Time
+}
+
+// ttlSet remembers keys for a window of time, assuming sightings arrive in time order
+type ttlSet[K comparable] struct {
+	window time.Duration
+	recent *list.List
+	seen   map[K]*list.Element
+}
+
+func newTTLSet[K comparable](window time.Duration) *ttlSet[K] {
+	return &ttlSet[K]{window: window, recent: list.New(), seen: make(map[K]*list.Element)}
+}
+
+// check records a sighting of k at now, reporting whether k had already been seen within the window
+func (t *ttlSet[K]) check(k K, now time.Time) bool {
+	for t.recent.Len() > 0 {
+		oldest := t.recent.Front().Value.(sighting[K])
+		if now.Sub(oldest.at) < t.window {
+			break
+		}
+		t.recent.Remove(t.recent.Front())
+		delete(t.seen, oldest.key)
+	}
+
+	if e, ok := t.seen[k]; ok {
+		e.Value = sighting[K]{k, now}
+		t.recent.MoveToBack(e)
+		return true
+	}
+	t.seen[k] = t.recent.PushBack(sighting[K]{k, now})
+	return false
+}
+
+// DedupWithinDuration yields the values of the time-ordered sequence s, suppressing any value whose key was last seen less than window earlier according to ts. Keys not seen within the window are forgotten, keeping memory bounded for infinite streams
+func DedupWithinDuration[T any, K comparable](s iter.Seq[T], key func(T) K, ts func(T) time.Time, window time.Duration) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := newTTLSet[K](window)
+		for v := range s {
+			if seen.check(key(v), ts(v)) {
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Unique2Within yields the pairs of the time-ordered sequence s, suppressing any pair whose key was last seen less than ttl earlier according to now, as needed to process at-least-once deliveries idempotently
+func Unique2Within[K comparable, V any](s iter.Seq2[K, V], ttl time.Duration, now func(V) time.Time) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		seen := newTTLSet[K](ttl)
+		for k, v := range s {
+			if seen.check(k, now(v)) {
+				continue
+			}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
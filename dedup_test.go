@@ -0,0 +1,84 @@
+package itertools
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistinct(t *testing.T) {
+	assertSequenceMatch(t, Distinct(NewSeq(3, 1, 3, 2, 1, 4)), []int{3, 1, 2, 4})
+	assertSequenceMatch(t, Distinct(NewSeq[string]()), []string{})
+	assertSequenceMatch(t, Take(Distinct(Map(func(x int) int { return x % 3 }, Count())), 3), []int{0, 1, 2})
+}
+
+func TestDistinctBy(t *testing.T) {
+	assertSequenceMatch(t,
+		DistinctBy(strings.ToLower, NewSeq("Go", "rust", "GO", "Rust", "zig")),
+		[]string{"Go", "rust", "zig"},
+	)
+}
+
+func TestDedupConsecutive(t *testing.T) {
+	assertSequenceMatch(t, DedupConsecutive(NewSeq(1, 1, 2, 2, 2, 1, 3, 3)), []int{1, 2, 1, 3})
+	assertSequenceMatch(t, DedupConsecutive(NewSeq(0, 0, 1)), []int{0, 1})
+	assertSequenceMatch(t, DedupConsecutive(NewSeq[int]()), []int{})
+	assertSequenceMatch(t, Take(DedupConsecutive(Map(func(x int) int { return x / 3 }, Count())), 3), []int{0, 1, 2})
+}
+
+func TestDedupConsecutiveBy(t *testing.T) {
+	assertSequenceMatch(t,
+		DedupConsecutiveBy(strings.ToLower, NewSeq("a", "A", "b", "a", "B", "b")),
+		[]string{"a", "b", "a", "B"},
+	)
+}
+
+func TestDedupWithin(t *testing.T) {
+	assertSequenceMatch(t,
+		DedupWithin(NewSeq(1, 2, 1, 3, 4, 1, 2, 2, 5), 2),
+		[]int{1, 2, 3, 4, 1, 2, 5},
+	)
+
+	assertSequenceMatch(t,
+		DedupWithin(NewSeq(1, 2, 1, 3, 1, 2), 3),
+		[]int{1, 2, 3},
+	)
+}
+
+func TestDedupWithinDuration(t *testing.T) {
+	type event struct {
+		id string
+		at int
+	}
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	id := func(e event) string { return e.id }
+	ts := func(e event) time.Time { return base.Add(time.Duration(e.at) * time.Second) }
+
+	assertSequenceMatch(t,
+		DedupWithinDuration(NewSeq(
+			event{"a", 0},
+			event{"b", 1},
+			event{"a", 2},
+			event{"b", 7},
+			event{"a", 8},
+			event{"b", 9},
+		), id, ts, 5*time.Second),
+		[]event{{"a", 0}, {"b", 1}, {"b", 7}, {"a", 8}},
+	)
+}
+
+func TestUnique2Within(t *testing.T) {
+	deliveries := func(yield func(string, timed) bool) {
+		for _, e := range []timed{{"m1", 0}, {"m2", 1}, {"m1", 3}, {"m1", 12}, {"m2", 20}} {
+			if !yield(e.name, e) {
+				return
+			}
+		}
+	}
+
+	keys, vals := toSlice2(Unique2Within(deliveries, 5*time.Second, timedAt))
+	assert.Equal(t, []string{"m1", "m2", "m1", "m2"}, keys)
+	assert.Equal(t, []timed{{"m1", 0}, {"m2", 1}, {"m1", 12}, {"m2", 20}}, vals)
+}
@@ -0,0 +1,247 @@
+package itertools
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"runtime/debug"
+	"time"
+)
+
+// ErrPartialBatch is returned by [ForEachBatch] under [ErrorOnPartial] when values are left over after the last full batch
+var ErrPartialBatch = errors.New("itertools: partial final batch")
+
+// ErrGroupOverflow is reported by [TryGroupBy], [TryGroupByFunc] and [TryChunkWhile] under [ErrorOnOverflow] when a group exceeds the size given to [WithMaxGroupSize]
+var ErrGroupOverflow = errors.New("itertools: group too large")
+
+// ErrTrailingJSON is reported by [DecodeJSONArray] when more input follows the array
+var ErrTrailingJSON = errors.New("itertools: trailing data after JSON array")
+
+// ErrBreakerOpen is reported by [Breaker] when too many consecutive failures trip it
+var ErrBreakerOpen = errors.New("itertools: circuit breaker open")
+
+// TryFold reduces s into an accumulator starting from seed, stopping at the first error from op and returning it alongside the last good accumulator
+func TryFold[T any, A any](s iter.Seq[T], seed A, op func(A, T) (A, error)) (A, error) {
+	acc := seed
+	for v := range s {
+		next, err := op(acc, v)
+		if err != nil {
+			return acc, err
+		}
+		acc = next
+	}
+	return acc, nil
+}
+
+// TryForEach calls fn for each value in s, stopping at and returning the first error
+func TryForEach[T any](s iter.Seq[T], fn func(T) error) error {
+	for v := range s {
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CollectErrs gathers the successful values of s along with its errors, continuing past failures until max errors have been seen. A max of zero or less collects every error
+func CollectErrs[T any](s iter.Seq2[T, error], max int) ([]T, []error) {
+	vals := make([]T, 0)
+	var errs []error
+	for v, err := range s {
+		if err != nil {
+			errs = append(errs, err)
+			if max > 0 && len(errs) >= max {
+				break
+			}
+			continue
+		}
+		vals = append(vals, v)
+	}
+	return vals, errs
+}
+
+// CollectErr gathers the values of s up to its first error, returning them along with that error
+func CollectErr[T any](s iter.Seq2[T, error]) ([]T, error) {
+	vals := make([]T, 0)
+	for v, err := range s {
+		if err != nil {
+			return vals, err
+		}
+		vals = append(vals, v)
+	}
+	return vals, nil
+}
+
+// TryMap applies f to each successful value of s, yielding its result or error. Errors from s are passed through with the zero value of U
+func TryMap[T any, U any](f func(T) (U, error), s iter.Seq2[T, error]) iter.Seq2[U, error] {
+	return func(yield func(U, error) bool) {
+		for v, err := range s {
+			var u U
+			if err == nil {
+				u, err = f(v)
+			}
+			if !yield(u, err) {
+				return
+			}
+		}
+	}
+}
+
+// TryFilter yields the successful values of s for which pred returns true, along with every error from s or pred
+func TryFilter[T any](pred func(T) (bool, error), s iter.Seq2[T, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for v, err := range s {
+			keep := true
+			if err == nil {
+				keep, err = pred(v)
+			}
+			if keep || err != nil {
+				if !yield(v, err) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// StopOnError yields the pairs of s up to and including its first error, then ends
+func StopOnError[T any](s iter.Seq2[T, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for v, err := range s {
+			if !yield(v, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// BreakerConfig controls when a [Breaker] trips and whether it recovers
+type BreakerConfig struct {
+	// Threshold is the number of consecutive failures that trips the breaker
+	Threshold int
+	// Window, if positive, only counts consecutive failures that all occur within this span of time
+	Window time.Duration
+	// ResetAfter, if positive, closes the breaker again after this delay instead of ending the sequence
+	ResetAfter time.Duration
+	// Clock, if set, replaces [SystemClock] for measuring the window and waiting to reset
+	Clock Clock
+}
+
+// Breaker passes through the values and errors of s until cfg.Threshold consecutive failures are seen, at which point it yields an error wrapping [ErrBreakerOpen] and the last failure. Without a ResetAfter the sequence then ends, otherwise it waits and resumes pulling from s
+func Breaker[T any](s iter.Seq2[T, error], cfg BreakerConfig) iter.Seq2[T, error] {
+	clock := clockOrSystem(cfg.Clock)
+	return func(yield func(T, error) bool) {
+		var failures int
+		var firstFailure time.Time
+
+		for v, err := range s {
+			if err == nil {
+				failures = 0
+				if !yield(v, nil) {
+					return
+				}
+				continue
+			}
+
+			now := clock.Now()
+			if failures == 0 || (cfg.Window > 0 && now.Sub(firstFailure) > cfg.Window) {
+				failures = 0
+				firstFailure = now
+			}
+			failures++
+
+			if !yield(v, err) {
+				return
+			}
+
+			if failures < cfg.Threshold {
+				continue
+			}
+
+			var zero T
+			if !yield(zero, fmt.Errorf("%w: %w", ErrBreakerOpen, err)) || cfg.ResetAfter <= 0 {
+				return
+			}
+			clock.Sleep(cfg.ResetAfter)
+			failures = 0
+		}
+	}
+}
+
+// PanicError is the error yielded by [Recover] when an upstream stage panics
+type PanicError struct {
+	// Value is the value passed to panic
+	Value any
+	// Stack is the goroutine stack trace captured at the point of recovery
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("itertools: recovered panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Recover yields the values of s, converting a panic raised while producing them into a terminal [*PanicError]. Panics raised by the consumer's own loop body are not recovered
+func Recover[T any](s iter.Seq[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var inYield bool
+		defer func() {
+			if inYield {
+				return
+			}
+			if r := recover(); r != nil {
+				var zero T
+				yield(zero, &PanicError{Value: r, Stack: debug.Stack()})
+			}
+		}()
+
+		for v := range s {
+			inYield = true
+			if !yield(v, nil) {
+				return
+			}
+			inYield = false
+		}
+	}
+}
+
+// PartialBatchPolicy decides what [ForEachBatch] does with a final batch holding fewer than n values
+type PartialBatchPolicy int
+
+const (
+	// FlushPartial passes the final partial batch to fn like any other
+	FlushPartial PartialBatchPolicy = iota
+	// DropPartial discards the final partial batch
+	DropPartial
+	// ErrorOnPartial discards the final partial batch and returns [ErrPartialBatch]
+	ErrorOnPartial
+)
+
+// ForEachBatch groups s into batches of n values and calls fn with each, stopping at and returning the first error. The final batch is handled according to policy, which defaults to [FlushPartial]
+func ForEachBatch[T any](s iter.Seq[T], n int, fn func([]T) error, policy ...PartialBatchPolicy) error {
+	partial := FlushPartial
+	if len(policy) > 0 {
+		partial = policy[0]
+	}
+
+	for batch := range Batched(s, n) {
+		if len(batch) < n {
+			switch partial {
+			case DropPartial:
+				return nil
+			case ErrorOnPartial:
+				return fmt.Errorf("%w: %d of %d values", ErrPartialBatch, len(batch), n)
+			}
+		}
+
+		if err := fn(batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
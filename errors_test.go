@@ -0,0 +1,240 @@
+package itertools
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/astonm/go-itertools/ittest"
+	"github.com/stretchr/testify/assert"
+)
+
+var errTest = errors.New("test error")
+
+func TestTryFold(t *testing.T) {
+	sum, err := TryFold(NewSeq(1, 2, 3), 0, func(acc, x int) (int, error) { return acc + x, nil })
+	assert.NoError(t, err)
+	assert.Equal(t, 6, sum)
+
+	sum, err = TryFold(NewSeq(1, 2, 3, 4), 0, func(acc, x int) (int, error) {
+		if x == 3 {
+			return 0, errTest
+		}
+		return acc + x, nil
+	})
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, 3, sum)
+}
+
+func TestTryForEach(t *testing.T) {
+	seen := make([]int, 0)
+	err := TryForEach(Count(), func(x int) error {
+		if x == 3 {
+			return errTest
+		}
+		seen = append(seen, x)
+		return nil
+	})
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, []int{0, 1, 2}, seen)
+
+	assert.NoError(t, TryForEach(NewSeq(1, 2), func(int) error { return nil }))
+}
+
+func fallible(vals ...int) iter.Seq2[int, error] {
+	return func(yield func(int, error) bool) {
+		for _, v := range vals {
+			var err error
+			if v < 0 {
+				err = fmt.Errorf("bad value %d", v)
+			}
+			if !yield(v, err) {
+				return
+			}
+		}
+	}
+}
+
+func TestCollectErr(t *testing.T) {
+	vals, err := CollectErr(fallible(1, 2, -3, 4))
+	assert.Equal(t, []int{1, 2}, vals)
+	assert.EqualError(t, err, "bad value -3")
+
+	vals, err = CollectErr(fallible(1, 2))
+	assert.Equal(t, []int{1, 2}, vals)
+	assert.NoError(t, err)
+}
+
+func TestTryMap(t *testing.T) {
+	half := func(v int) (float64, error) {
+		if v%2 != 0 {
+			return 0, fmt.Errorf("odd value %d", v)
+		}
+		return float64(v) / 2, nil
+	}
+
+	vals, errs := CollectErrs(TryMap(half, fallible(2, -4, 3, 8)), 0)
+	assert.Equal(t, []float64{1, 4}, vals)
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "bad value -4")
+	assert.EqualError(t, errs[1], "odd value 3")
+
+	vals, err := CollectErr(TryMap(half, fallible(2, 3, 4)))
+	assert.Equal(t, []float64{1}, vals)
+	assert.EqualError(t, err, "odd value 3")
+}
+
+func TestTryFilter(t *testing.T) {
+	small := func(v int) (bool, error) {
+		if v > 100 {
+			return false, fmt.Errorf("value %d out of range", v)
+		}
+		return v < 10, nil
+	}
+
+	vals, errs := CollectErrs(TryFilter(small, fallible(1, 20, -3, 4, 200, 5)), 0)
+	assert.Equal(t, []int{1, 4, 5}, vals)
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "bad value -3")
+	assert.EqualError(t, errs[1], "value 200 out of range")
+}
+
+func TestStopOnError(t *testing.T) {
+	vals, errs := CollectErrs(StopOnError(fallible(1, 2, -3, 4, -5)), 0)
+	assert.Equal(t, []int{1, 2}, vals)
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "bad value -3")
+
+	vals, errs = CollectErrs(StopOnError(fallible(1, 2)), 0)
+	assert.Equal(t, []int{1, 2}, vals)
+	assert.Empty(t, errs)
+}
+
+func TestCollectErrs(t *testing.T) {
+	vals, errs := CollectErrs(fallible(1, -2, 3, -4, 5, -6), 0)
+	assert.Equal(t, []int{1, 3, 5}, vals)
+	assert.Len(t, errs, 3)
+
+	vals, errs = CollectErrs(fallible(1, -2, 3, -4, 5, -6), 2)
+	assert.Equal(t, []int{1, 3}, vals)
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[1], "bad value -4")
+}
+
+func TestBreaker(t *testing.T) {
+	var vals []int
+	var errs []error
+	for v, err := range Breaker(fallible(1, -2, 3, -4, -5, -6, 7), BreakerConfig{Threshold: 2}) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		vals = append(vals, v)
+	}
+	assert.Equal(t, []int{1, 3}, vals)
+	assert.Len(t, errs, 4)
+	assert.ErrorIs(t, errs[3], ErrBreakerOpen)
+	assert.EqualError(t, errs[3], "itertools: circuit breaker open: bad value -5")
+
+	c := ittest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	start := c.Now()
+	vals, errs = CollectErrs(Breaker(fallible(-1, -2, 3, -4, -5, 6), BreakerConfig{Threshold: 2, ResetAfter: time.Minute, Clock: c}), 0)
+	assert.Equal(t, []int{3, 6}, vals)
+	assert.Len(t, errs, 6)
+	assert.ErrorIs(t, errs[2], ErrBreakerOpen)
+	assert.ErrorIs(t, errs[5], ErrBreakerOpen)
+	assert.Equal(t, 2*time.Minute, c.Now().Sub(start))
+}
+
+func TestBreakerWindow(t *testing.T) {
+	c := ittest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	errBackend := errors.New("backend down")
+
+	// each failure takes 10s, so only two fit in a 15s window
+	var src iter.Seq2[int, error] = func(yield func(int, error) bool) {
+		for i := range 4 {
+			c.Sleep(10 * time.Second)
+			if !yield(i, errBackend) {
+				return
+			}
+		}
+	}
+
+	countOpened := func(s iter.Seq2[int, error]) int {
+		var opened int
+		for _, err := range s {
+			if errors.Is(err, ErrBreakerOpen) {
+				opened++
+			}
+		}
+		return opened
+	}
+
+	assert.Equal(t, 0, countOpened(Breaker(src, BreakerConfig{Threshold: 3, Window: 15 * time.Second, Clock: c})))
+
+	before := c.Now()
+	assert.Equal(t, 1, countOpened(Breaker(src, BreakerConfig{Threshold: 3, Window: time.Minute, ResetAfter: time.Hour, Clock: c})))
+	assert.Equal(t, time.Hour+40*time.Second, c.Now().Sub(before))
+}
+
+func TestRecover(t *testing.T) {
+	panicky := Map(func(x int) int {
+		if x == 3 {
+			panic(errTest)
+		}
+		return x
+	}, Count())
+
+	vals, errs := CollectErrs(Recover(panicky), 0)
+	assert.Equal(t, []int{0, 1, 2}, vals)
+	assert.Len(t, errs, 1)
+
+	var pe *PanicError
+	assert.ErrorAs(t, errs[0], &pe)
+	assert.ErrorIs(t, errs[0], errTest)
+	assert.NotEmpty(t, pe.Stack)
+
+	assert.PanicsWithValue(t, "consumer", func() {
+		for range Recover(NewSeq(1, 2, 3)) {
+			panic("consumer")
+		}
+	})
+}
+
+func TestForEachBatch(t *testing.T) {
+	var batches [][]int
+	record := func(b []int) error {
+		batches = append(batches, b)
+		return nil
+	}
+
+	assert.NoError(t, ForEachBatch(NewSeq(1, 2, 3, 4, 5), 2, record))
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, batches)
+
+	batches = nil
+	assert.NoError(t, ForEachBatch(NewSeq(1, 2, 3, 4, 5), 2, record, DropPartial))
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}}, batches)
+
+	batches = nil
+	err := ForEachBatch(NewSeq(1, 2, 3, 4, 5), 2, record, ErrorOnPartial)
+	assert.ErrorIs(t, err, ErrPartialBatch)
+	assert.EqualError(t, err, "itertools: partial final batch: 1 of 2 values")
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}}, batches)
+
+	batches = nil
+	assert.NoError(t, ForEachBatch(NewSeq(1, 2, 3, 4), 2, record, ErrorOnPartial))
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}}, batches)
+
+	var calls int
+	err = ForEachBatch(Count(), 3, func(b []int) error {
+		calls++
+		if b[0] >= 6 {
+			return errTest
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, 3, calls)
+}
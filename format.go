@@ -0,0 +1,162 @@
+package itertools
+
+import (
+	"io"
+	"iter"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+)
+
+// RenderEach executes tmpl once per value of s, writing the output to w as it goes. It stops at and returns the first error from executing the template
+func RenderEach[T any](s iter.Seq[T], tmpl *template.Template, w io.Writer) error {
+	for v := range s {
+		if err := tmpl.Execute(w, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Column describes one column of a table written by [WriteTable]
+type Column[T any] struct {
+	// Header is the column title
+	Header string
+	// Value formats the cell for a value
+	Value func(T) string
+	// Width fixes the width of the column in runes. If zero, the column fits its header and the widest cell among the first rows
+	Width int
+	// Right aligns cells to the right, as suits numbers
+	Right bool
+}
+
+// tableSampleRows is the number of rows WriteTable buffers to size columns without a fixed width
+const tableSampleRows = 100
+
+// TableOption configures [WriteTable]
+type TableOption func(*tableOptions)
+
+type tableOptions struct {
+	maxRows int
+}
+
+// WithMaxRows limits a table to its first n rows, ending it with an ellipsis line if s holds more
+func WithMaxRows(n int) TableOption {
+	return func(o *tableOptions) {
+		o.maxRows = n
+	}
+}
+
+// WriteTable writes the values of s to w as a column-aligned table with a header row. Rows are written as they arrive once the first few have been seen to size the columns, and cells wider than their column are truncated with an ellipsis
+func WriteTable[T any](w io.Writer, s iter.Seq[T], columns []Column[T], opts ...TableOption) error {
+	o := tableOptions{maxRows: -1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	widths := make([]int, len(columns))
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.Header
+		widths[i] = c.Width
+	}
+
+	var pending [][]string
+	sized := false
+	writeRow := func(cells []string) error {
+		if !sized {
+			pending = append(pending, cells)
+			if len(pending) <= tableSampleRows {
+				return nil
+			}
+
+			sized = true
+			fitColumns(columns, widths, pending)
+			for _, row := range pending {
+				if err := writeTableRow(w, columns, widths, row); err != nil {
+					return err
+				}
+			}
+			pending = nil
+			return nil
+		}
+		return writeTableRow(w, columns, widths, cells)
+	}
+
+	if err := writeRow(header); err != nil {
+		return err
+	}
+
+	var rows int
+	for v := range s {
+		if rows == o.maxRows {
+			cells := make([]string, len(columns))
+			for i := range cells {
+				cells[i] = "…"
+			}
+			if err := writeRow(cells); err != nil {
+				return err
+			}
+			break
+		}
+
+		cells := make([]string, len(columns))
+		for i, c := range columns {
+			cells[i] = c.Value(v)
+		}
+		if err := writeRow(cells); err != nil {
+			return err
+		}
+		rows++
+	}
+
+	if !sized {
+		fitColumns(columns, widths, pending)
+		for _, row := range pending {
+			if err := writeTableRow(w, columns, widths, row); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fitColumns sets the width of each column without a fixed one to its widest cell among rows
+func fitColumns[T any](columns []Column[T], widths []int, rows [][]string) {
+	for i, c := range columns {
+		if c.Width > 0 {
+			continue
+		}
+		for _, row := range rows {
+			widths[i] = max(widths[i], utf8.RuneCountInString(row[i]))
+		}
+	}
+}
+
+func writeTableRow[T any](w io.Writer, columns []Column[T], widths []int, cells []string) error {
+	var sb strings.Builder
+	for i, cell := range cells {
+		if i > 0 {
+			sb.WriteString("  ")
+		}
+
+		n := utf8.RuneCountInString(cell)
+		if n > widths[i] {
+			cell = string([]rune(cell)[:max(widths[i]-1, 0)]) + "…"
+			n = widths[i]
+		}
+
+		pad := strings.Repeat(" ", widths[i]-n)
+		if columns[i].Right {
+			sb.WriteString(pad + cell)
+		} else if i < len(cells)-1 {
+			sb.WriteString(cell + pad)
+		} else {
+			sb.WriteString(cell)
+		}
+	}
+	sb.WriteByte('\n')
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,101 @@
+package itertools
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderEach(t *testing.T) {
+	type row struct {
+		Name  string
+		Count int
+	}
+	tmpl := template.Must(template.New("row").Parse("{{.Name}}: {{.Count}}\n"))
+
+	var sb strings.Builder
+	err := RenderEach(NewSeq(row{"a", 1}, row{"b", 2}), tmpl, &sb)
+	assert.NoError(t, err)
+	assert.Equal(t, "a: 1\nb: 2\n", sb.String())
+
+	sb.Reset()
+	bad := template.Must(template.New("bad").Parse("{{.Missing}}\n"))
+	err = RenderEach(NewSeq(row{"a", 1}, row{"b", 2}), bad, &sb)
+	assert.Error(t, err)
+	assert.Empty(t, sb.String())
+
+	sb.Reset()
+	err = RenderEach(Take(Count(), 3), template.Must(template.New("n").Parse("[{{.}}]")), &sb)
+	assert.NoError(t, err)
+	assert.Equal(t, "[0][1][2]", sb.String())
+}
+
+func TestWriteTable(t *testing.T) {
+	type city struct {
+		name string
+		pop  int
+	}
+	cities := NewSeq(city{"Oslo", 709000}, city{"Reykjavík", 139000}, city{"Tórshavn", 14000})
+	columns := []Column[city]{
+		{Header: "City", Value: func(c city) string { return c.name }},
+		{Header: "Population", Value: func(c city) string { return strconv.Itoa(c.pop) }, Right: true},
+	}
+
+	var sb strings.Builder
+	assert.NoError(t, WriteTable(&sb, cities, columns))
+	assert.Equal(t, ""+
+		"City       Population\n"+
+		"Oslo           709000\n"+
+		"Reykjavík      139000\n"+
+		"Tórshavn        14000\n",
+		sb.String(),
+	)
+
+	sb.Reset()
+	columns[0].Width = 6
+	assert.NoError(t, WriteTable(&sb, cities, columns, WithMaxRows(2)))
+	assert.Equal(t, ""+
+		"City    Population\n"+
+		"Oslo        709000\n"+
+		"Reykj…      139000\n"+
+		"…                …\n",
+		sb.String(),
+	)
+
+	sb.Reset()
+	assert.NoError(t, WriteTable(&sb, NewSeq[city](), columns))
+	assert.Equal(t, "City    Population\n", sb.String())
+}
+
+func TestWriteTableStreams(t *testing.T) {
+	columns := []Column[int]{{Header: "n", Value: strconv.Itoa}}
+
+	var sb strings.Builder
+	rows := tableSampleRows + 5
+	assert.NoError(t, WriteTable(&sb, Take(Count(), rows), columns))
+	lines := strings.Split(strings.TrimSuffix(sb.String(), "\n"), "\n")
+	assert.Len(t, lines, rows+1)
+	assert.Equal(t, "n", lines[0])
+	assert.Equal(t, "99", lines[100])
+	// rows after the sample keep the sampled width
+	assert.Equal(t, "1…", lines[rows])
+
+	// the sample is written before the rest of the sequence is read
+	sb.Reset()
+	var written int
+	err := WriteTable(&sb, func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if i == tableSampleRows {
+				written = strings.Count(sb.String(), "\n")
+			}
+			if !yield(i) {
+				return
+			}
+		}
+	}, columns, WithMaxRows(tableSampleRows+3))
+	assert.NoError(t, err)
+	assert.Equal(t, tableSampleRows+1, written)
+}
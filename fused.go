@@ -0,0 +1,38 @@
+package itertools
+
+import "iter"
+
+// MapFilter is equivalent to Filter(pred, Map(mapper, s)) but runs both stages in a single loop body
+func MapFilter[T any, U any](mapper func(T) U, pred func(U) bool, s iter.Seq[T]) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range s {
+			u := mapper(v)
+			if pred(u) && !yield(u) {
+				return
+			}
+		}
+	}
+}
+
+// FilterMapTake is equivalent to Take(Map(mapper, Filter(pred, s)), n) but runs all three stages in a single loop body
+func FilterMapTake[T any, U any](pred func(T) bool, mapper func(T) U, s iter.Seq[T], n int) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		if n <= 0 {
+			return
+		}
+
+		var taken int
+		for v := range s {
+			if !pred(v) {
+				continue
+			}
+			if !yield(mapper(v)) {
+				return
+			}
+			taken++
+			if taken == n {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package itertools
+
+import (
+	"testing"
+
+	"github.com/astonm/go-itertools/benchdata"
+)
+
+func isEven(x int) bool { return x%2 == 0 }
+func square(x int) int  { return x * x }
+
+func TestMapFilter(t *testing.T) {
+	assertSequenceMatch(t,
+		MapFilter(square, func(x int) bool { return x%3 == 1 }, NewSeq(1, 2, 3, 4, 5)),
+		[]int{1, 4, 16, 25},
+	)
+}
+
+func TestFilterMapTake(t *testing.T) {
+	assertSequenceMatch(t, FilterMapTake(isEven, square, Count(), 3), []int{0, 4, 16})
+	assertSequenceMatch(t, FilterMapTake(isEven, square, NewSeq(1, 2, 3), 5), []int{4})
+	assertSequenceMatch(t, FilterMapTake(isEven, square, Count(), 0), []int{})
+}
+
+var benchInts = benchdata.MakeInts(10000)
+
+func BenchmarkMapFilterStaged(b *testing.B) {
+	for range b.N {
+		for range Filter(isEven, Map(square, FromSlice(benchInts))) {
+		}
+	}
+}
+
+func BenchmarkMapFilterFused(b *testing.B) {
+	for range b.N {
+		for range MapFilter(square, isEven, FromSlice(benchInts)) {
+		}
+	}
+}
+
+func BenchmarkFilterMapTakeStaged(b *testing.B) {
+	for range b.N {
+		for range Take(Map(square, Filter(isEven, FromSlice(benchInts))), 1000) {
+		}
+	}
+}
+
+func BenchmarkFilterMapTakeFused(b *testing.B) {
+	for range b.N {
+		for range FilterMapTake(isEven, square, FromSlice(benchInts), 1000) {
+		}
+	}
+}
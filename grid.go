@@ -0,0 +1,97 @@
+package itertools
+
+import (
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Indices yields every multi-dimensional index tuple within dims in row-major order, with the last index varying fastest, like numpy.ndindex. Nothing is yielded if any dimension is empty, while no dimensions at all yield a single empty tuple
+func Indices(dims ...int) iter.Seq[[]int] {
+	return Map(slices.Clone, odometer(dims...))
+}
+
+// odometer is [Indices] reusing a single slice that is only valid until the next iteration
+func odometer(dims ...int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		for _, d := range dims {
+			if d <= 0 {
+				return
+			}
+		}
+
+		idx := make([]int, len(dims))
+		for {
+			if !yield(idx) {
+				return
+			}
+
+			i := len(dims) - 1
+			for ; i >= 0; i-- {
+				idx[i]++
+				if idx[i] < dims[i] {
+					break
+				}
+				idx[i] = 0
+			}
+			if i < 0 {
+				return
+			}
+		}
+	}
+}
+
+// GridSearch yields one map per combination of parameter values, iterating parameters in sorted name order with the last name varying fastest
+func GridSearch(params map[string][]any) iter.Seq[map[string]any] {
+	names := slices.Sorted(maps.Keys(params))
+	dims := make([]int, len(names))
+	for i, name := range names {
+		dims[i] = len(params[name])
+	}
+
+	return func(yield func(map[string]any) bool) {
+		for idx := range odometer(dims...) {
+			point := make(map[string]any, len(names))
+			for i, name := range names {
+				point[name] = params[name][idx[i]]
+			}
+			if !yield(point) {
+				return
+			}
+		}
+	}
+}
+
+// GridParam is one dimension of a [GridSearchInto] sweep, created with [Param]
+type GridParam[S any] struct {
+	n     int
+	apply func(*S, int)
+}
+
+// Param returns a [GridParam] that sweeps values, storing each into the struct being filled with set
+func Param[S any, V any](values []V, set func(*S, V)) GridParam[S] {
+	return GridParam[S]{
+		n:     len(values),
+		apply: func(s *S, i int) { set(s, values[i]) },
+	}
+}
+
+// GridSearchInto yields a copy of base for every combination of the given parameters' values, with the last parameter varying fastest
+func GridSearchInto[S any](base S, params ...GridParam[S]) iter.Seq[S] {
+	dims := make([]int, len(params))
+	for i, p := range params {
+		dims[i] = p.n
+	}
+
+	return func(yield func(S) bool) {
+		for idx := range odometer(dims...) {
+			point := base
+			for i, p := range params {
+				p.apply(&point, idx[i])
+			}
+			if !yield(point) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package itertools
+
+import "testing"
+
+func TestIndices(t *testing.T) {
+	assertSequenceMatch(t, Indices(2, 3), [][]int{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {1, 1}, {1, 2}})
+	assertSequenceMatch(t, Indices(3), [][]int{{0}, {1}, {2}})
+	assertSequenceMatch(t, Indices(2, 0, 2), [][]int{})
+	assertSequenceMatch(t, Indices(), [][]int{{}})
+	assertSequenceMatch(t, Take(Indices(1000, 1000), 2), [][]int{{0, 0}, {0, 1}})
+}
+
+func TestGridSearch(t *testing.T) {
+	assertSequenceMatch(t,
+		GridSearch(map[string][]any{
+			"lr":    {0.1, 0.01},
+			"depth": {2, 4},
+		}),
+		[]map[string]any{
+			{"depth": 2, "lr": 0.1},
+			{"depth": 2, "lr": 0.01},
+			{"depth": 4, "lr": 0.1},
+			{"depth": 4, "lr": 0.01},
+		},
+	)
+
+	assertSequenceMatch(t, GridSearch(map[string][]any{"a": {1}, "b": {}}), []map[string]any{})
+	assertSequenceMatch(t, GridSearch(nil), []map[string]any{{}})
+}
+
+func TestGridSearchInto(t *testing.T) {
+	type config struct {
+		Name  string
+		Depth int
+		Fast  bool
+	}
+
+	assertSequenceMatch(t,
+		GridSearchInto(config{Name: "base"},
+			Param([]int{1, 2}, func(c *config, v int) { c.Depth = v }),
+			Param([]bool{false, true}, func(c *config, v bool) { c.Fast = v }),
+		),
+		[]config{
+			{"base", 1, false},
+			{"base", 1, true},
+			{"base", 2, false},
+			{"base", 2, true},
+		},
+	)
+}
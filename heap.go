@@ -0,0 +1,26 @@
+package itertools
+
+import "container/heap"
+
+// funcHeap is a binary heap of T ordered by less, for use with container/heap
+type funcHeap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func (h *funcHeap[T]) Len() int           { return len(h.items) }
+func (h *funcHeap[T]) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *funcHeap[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *funcHeap[T]) Push(x any)         { h.items = append(h.items, x.(T)) }
+
+func (h *funcHeap[T]) Pop() any {
+	var zero T
+	n := len(h.items) - 1
+	item := h.items[n]
+	h.items[n] = zero
+	h.items = h.items[:n]
+	return item
+}
+
+func (h *funcHeap[T]) push(v T) { heap.Push(h, v) }
+func (h *funcHeap[T]) pop() T   { return heap.Pop(h).(T) }
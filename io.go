@@ -0,0 +1,351 @@
+package itertools
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"strings"
+	"sync"
+)
+
+// LineRecord is a line of text read by [LinesWithOffset] along with its position in the underlying reader
+type LineRecord struct {
+	// Text is the line contents without the trailing line ending
+	Text string
+	// Offset is the byte offset of the start of the line
+	Offset int64
+	// End is the byte offset just past the line ending, i.e. where reading should resume
+	End int64
+}
+
+// LinesWithOffset reads r line by line from its current position, yielding each line with its byte offsets
+func LinesWithOffset(r io.ReadSeeker) iter.Seq2[LineRecord, error] {
+	return func(yield func(LineRecord, error) bool) {
+		offset, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			yield(LineRecord{}, err)
+			return
+		}
+
+		br := bufio.NewReader(r)
+		for {
+			line, err := br.ReadString('\n')
+			if len(line) > 0 {
+				rec := LineRecord{
+					Text:   strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"),
+					Offset: offset,
+					End:    offset + int64(len(line)),
+				}
+				offset = rec.End
+				if !yield(rec, nil) {
+					return
+				}
+			}
+
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(LineRecord{}, err)
+				return
+			}
+		}
+	}
+}
+
+// SeekToOffset positions r at offset, typically a previously checkpointed [LineRecord].End, and resumes reading lines from there
+func SeekToOffset(r io.ReadSeeker, offset int64) iter.Seq2[LineRecord, error] {
+	return func(yield func(LineRecord, error) bool) {
+		if _, err := r.Seek(offset, io.SeekStart); err != nil {
+			yield(LineRecord{}, err)
+			return
+		}
+
+		for rec, err := range LinesWithOffset(r) {
+			if !yield(rec, err) {
+				return
+			}
+		}
+	}
+}
+
+// Decompressor wraps a compressed stream in a reader of its decompressed contents
+type Decompressor func(io.Reader) (io.Reader, error)
+
+type openOptions struct {
+	formats []compression
+}
+
+type compression struct {
+	magic      []byte
+	decompress Decompressor
+}
+
+// OpenOption configures [OpenLines]
+type OpenOption func(*openOptions)
+
+// WithDecompressor registers decompress for files beginning with the given magic bytes, e.g. to add zstd support
+func WithDecompressor(magic []byte, decompress Decompressor) OpenOption {
+	return func(o *openOptions) {
+		o.formats = append(o.formats, compression{magic, decompress})
+	}
+}
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+func gunzip(r io.Reader) (io.Reader, error) {
+	return gzip.NewReader(r)
+}
+
+// OpenLines reads the file at path line by line, transparently decompressing gzip files and any formats registered with [WithDecompressor]. The file is closed when iteration finishes or stops early
+func OpenLines(path string, opts ...OpenOption) iter.Seq2[string, error] {
+	o := openOptions{formats: []compression{{gzipMagic, gunzip}}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(yield func(string, error) bool) {
+		f, err := os.Open(path)
+		if err != nil {
+			yield("", err)
+			return
+		}
+		defer f.Close()
+
+		br := bufio.NewReader(f)
+		var r io.Reader = br
+		for _, c := range o.formats {
+			head, _ := br.Peek(len(c.magic))
+			if !bytes.Equal(head, c.magic) {
+				continue
+			}
+
+			r, err = c.decompress(br)
+			if err != nil {
+				yield("", err)
+				return
+			}
+			if rc, ok := r.(io.Closer); ok {
+				defer rc.Close()
+			}
+			break
+		}
+
+		for line, err := range lines(r) {
+			if !yield(line, err) {
+				return
+			}
+		}
+	}
+}
+
+// lines yields each line of r without its line ending, followed by any read error other than io.EOF
+func lines(r io.Reader) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		br := bufio.NewReader(r)
+		for {
+			line, err := br.ReadString('\n')
+			if len(line) > 0 {
+				if !yield(strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"), nil) {
+					return
+				}
+			}
+
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield("", err)
+				return
+			}
+		}
+	}
+}
+
+// ChainFiles lazily opens each path in turn, chaining the records parsed from each. Every file is closed once its records are exhausted or iteration stops early, and open, parse and close errors are yielded in place without ending the sequence
+func ChainFiles[T any](paths []string, open func(string) (io.ReadCloser, error), parse func(io.Reader) iter.Seq2[T, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for _, path := range paths {
+			rc, err := open(path)
+			if err != nil {
+				if !yield(zero, err) {
+					return
+				}
+				continue
+			}
+
+			stopped := false
+			for v, err := range parse(rc) {
+				if !yield(v, err) {
+					stopped = true
+					break
+				}
+			}
+
+			err = rc.Close()
+			if stopped {
+				return
+			}
+			if err != nil && !yield(zero, err) {
+				return
+			}
+		}
+	}
+}
+
+// With acquires a sequence and its cleanup function each time it is ranged over, guaranteeing cleanup runs whether iteration completes, stops early or panics. Acquire and cleanup errors are yielded, the latter only when iteration was not stopped early
+func With[T any](acquire func() (iter.Seq[T], func() error, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		s, cleanup, err := acquire()
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		stopped := true
+		defer func() {
+			if err := cleanup(); err != nil && !stopped {
+				yield(zero, err)
+			}
+		}()
+
+		for v := range s {
+			if !yield(v, nil) {
+				return
+			}
+		}
+		stopped = false
+	}
+}
+
+// OnClose wraps s so that cleanup is called whenever an iteration over it ends, including by early break or panic
+func OnClose[T any](s iter.Seq[T], cleanup func()) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		defer cleanup()
+		for v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// parallelChunkSize is the nominal number of bytes of a file read by each ParallelLines task
+var parallelChunkSize int64 = 4 << 20
+
+type chunkLines struct {
+	lines []string
+	err   error
+}
+
+// readChunk returns the lines of f starting within [start, end), skipping a line that began before start
+func readChunk(f io.ReaderAt, size, start, end int64) chunkLines {
+	offset := start
+	if start > 0 {
+		// back up one byte so a line starting exactly at start is not skipped
+		offset = start - 1
+	}
+
+	br := bufio.NewReader(io.NewSectionReader(f, offset, size-offset))
+	if start > 0 {
+		skipped, err := br.ReadString('\n')
+		offset += int64(len(skipped))
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			return chunkLines{err: err}
+		}
+	}
+
+	var out []string
+	for offset < end {
+		line, err := br.ReadString('\n')
+		offset += int64(len(line))
+		if len(line) > 0 {
+			out = append(out, strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"))
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return chunkLines{out, err}
+		}
+	}
+	return chunkLines{lines: out}
+}
+
+// ParallelLines reads the file at path line by line, splitting it into byte ranges aligned to line boundaries that are read by up to workers goroutines at once. Lines are still yielded in file order
+func ParallelLines(path string, workers int) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		f, err := os.Open(path)
+		if err != nil {
+			yield("", err)
+			return
+		}
+		defer f.Close()
+
+		info, err := f.Stat()
+		if err != nil {
+			yield("", err)
+			return
+		}
+		size := info.Size()
+		chunkSize := parallelChunkSize
+
+		// stop the producer and let in-flight reads finish before the file is closed
+		var wg sync.WaitGroup
+		done := make(chan struct{})
+		defer wg.Wait()
+		defer close(done)
+
+		workers = max(workers, 1)
+		pending := make(chan chan chunkLines, workers)
+		sem := make(chan struct{}, workers)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(pending)
+			for start := int64(0); start < size; start += chunkSize {
+				result := make(chan chunkLines, 1)
+				select {
+				case pending <- result:
+				case <-done:
+					return
+				}
+				select {
+				case sem <- struct{}{}:
+				case <-done:
+					return
+				}
+
+				wg.Add(1)
+				go func(start int64) {
+					defer wg.Done()
+					defer func() { <-sem }()
+					result <- readChunk(f, size, start, min(start+chunkSize, size))
+				}(start)
+			}
+		}()
+
+		for result := range pending {
+			chunk := <-result
+			for _, line := range chunk.lines {
+				if !yield(line, nil) {
+					return
+				}
+			}
+			if chunk.err != nil {
+				yield("", chunk.err)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package itertools
+
+import (
+	"compress/gzip"
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinesWithOffset(t *testing.T) {
+	r := strings.NewReader("alpha\r\nbeta\n\ngamma")
+
+	recs, errs := CollectErrs(LinesWithOffset(r), 0)
+	assert.Empty(t, errs)
+	assert.Equal(t, []LineRecord{
+		{"alpha", 0, 7},
+		{"beta", 7, 12},
+		{"", 12, 13},
+		{"gamma", 13, 18},
+	}, recs)
+}
+
+func TestSeekToOffset(t *testing.T) {
+	r := strings.NewReader("alpha\nbeta\ngamma\n")
+
+	var checkpoint int64
+	for rec, err := range LinesWithOffset(r) {
+		assert.NoError(t, err)
+		checkpoint = rec.End
+		if rec.Text == "alpha" {
+			break
+		}
+	}
+
+	recs, errs := CollectErrs(SeekToOffset(r, checkpoint), 0)
+	assert.Empty(t, errs)
+	assert.Equal(t, []LineRecord{{"beta", 6, 11}, {"gamma", 11, 17}}, recs)
+}
+
+func TestOpenLines(t *testing.T) {
+	dir := t.TempDir()
+
+	plain := filepath.Join(dir, "plain.txt")
+	assert.NoError(t, os.WriteFile(plain, []byte("a\nb\nc\n"), 0o644))
+
+	got, errs := CollectErrs(OpenLines(plain), 0)
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"a", "b", "c"}, got)
+
+	compressed := filepath.Join(dir, "compressed.txt.gz")
+	f, err := os.Create(compressed)
+	assert.NoError(t, err)
+	zw := gzip.NewWriter(f)
+	_, err = zw.Write([]byte("x\ny\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, zw.Close())
+	assert.NoError(t, f.Close())
+
+	got, errs = CollectErrs(OpenLines(compressed), 0)
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"x", "y"}, got)
+
+	custom := filepath.Join(dir, "custom.txt")
+	assert.NoError(t, os.WriteFile(custom, []byte("UP:hello\nworld\n"), 0o644))
+	upper := func(r io.Reader) (io.Reader, error) {
+		b, err := io.ReadAll(r)
+		return strings.NewReader(strings.ToUpper(string(b[3:]))), err
+	}
+
+	got, errs = CollectErrs(OpenLines(custom, WithDecompressor([]byte("UP:"), upper)), 0)
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"HELLO", "WORLD"}, got)
+
+	_, errs = CollectErrs(OpenLines(filepath.Join(dir, "missing.txt")), 0)
+	assert.Len(t, errs, 1)
+}
+
+type trackedCloser struct {
+	io.Reader
+	closed *[]string
+	name   string
+}
+
+func (c trackedCloser) Close() error {
+	*c.closed = append(*c.closed, c.name)
+	return nil
+}
+
+func TestChainFiles(t *testing.T) {
+	files := map[string]string{
+		"a": "1\n2\n",
+		"b": "3\n",
+		"c": "4\n5\n",
+	}
+
+	var closed []string
+	open := func(name string) (io.ReadCloser, error) {
+		contents, ok := files[name]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return trackedCloser{strings.NewReader(contents), &closed, name}, nil
+	}
+
+	got, errs := CollectErrs(ChainFiles([]string{"a", "missing", "b", "c"}, open, lines), 0)
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, got)
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], os.ErrNotExist)
+	assert.Equal(t, []string{"a", "b", "c"}, closed)
+
+	closed = nil
+	for line := range ChainFiles([]string{"a", "b", "c"}, open, lines) {
+		if line == "3" {
+			break
+		}
+	}
+	assert.Equal(t, []string{"a", "b"}, closed)
+}
+
+func TestWith(t *testing.T) {
+	var cleanups int
+	acquire := func() (iter.Seq[int], func() error, error) {
+		return NewSeq(1, 2, 3), func() error {
+			cleanups++
+			return errTest
+		}, nil
+	}
+
+	got, errs := CollectErrs(With(acquire), 0)
+	assert.Equal(t, []int{1, 2, 3}, got)
+	assert.Equal(t, []error{errTest}, errs)
+	assert.Equal(t, 1, cleanups)
+
+	for range With(acquire) {
+		break
+	}
+	assert.Equal(t, 2, cleanups)
+
+	assert.Panics(t, func() {
+		for range With(acquire) {
+			panic("boom")
+		}
+	})
+	assert.Equal(t, 3, cleanups)
+
+	failing := func() (iter.Seq[int], func() error, error) { return nil, nil, errTest }
+	_, errs = CollectErrs(With(failing), 0)
+	assert.Equal(t, []error{errTest}, errs)
+}
+
+func TestOnClose(t *testing.T) {
+	var closed bool
+	assertSequenceMatch(t, Take(OnClose(Count(), func() { closed = true }), 2), []int{0, 1})
+	assert.True(t, closed)
+}
+
+func TestParallelLines(t *testing.T) {
+	defer func(size int64) { parallelChunkSize = size }(parallelChunkSize)
+	parallelChunkSize = 7
+
+	var b strings.Builder
+	var want []string
+	for i := range 200 {
+		line := strings.Repeat("x", i%13) + strconv.Itoa(i)
+		if i%17 == 0 {
+			line = ""
+		}
+		want = append(want, line)
+		b.WriteString(line + "\n")
+	}
+
+	path := filepath.Join(t.TempDir(), "lines.txt")
+	assert.NoError(t, os.WriteFile(path, []byte(b.String()), 0o644))
+
+	for _, workers := range []int{1, 3, 8} {
+		got, errs := CollectErrs(ParallelLines(path, workers), 0)
+		assert.Empty(t, errs)
+		assert.Equal(t, want, got)
+	}
+
+	assert.NoError(t, os.WriteFile(path, []byte("a\nb\r\nc"), 0o644))
+	got, errs := CollectErrs(ParallelLines(path, 2), 0)
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"a", "b", "c"}, got)
+
+	var n int
+	for range ParallelLines(path, 2) {
+		n++
+		break
+	}
+	assert.Equal(t, 1, n)
+
+	_, errs = CollectErrs(ParallelLines(filepath.Join(t.TempDir(), "missing"), 2), 0)
+	assert.Len(t, errs, 1)
+}
@@ -0,0 +1,85 @@
+// Package itbytes provides sequence functions specialized for []byte values, avoiding the string conversions and copies of their generic counterparts
+package itbytes
+
+import (
+	"bytes"
+	"hash"
+	"iter"
+)
+
+// SplitBytes yields the subslices of data separated by sep, like [bytes.Split] but lazily and without allocating the result. The views share memory with data but are capped at their length, so appending to one never overwrites the rest of data. An empty sep splits after each byte
+func SplitBytes(data, sep []byte) iter.Seq[[]byte] {
+	return func(yield func([]byte) bool) {
+		if len(sep) == 0 {
+			for i := range data {
+				if !yield(data[i : i+1 : i+1]) {
+					return
+				}
+			}
+			return
+		}
+
+		for {
+			i := bytes.Index(data, sep)
+			if i < 0 {
+				yield(data[:len(data):len(data)])
+				return
+			}
+			if !yield(data[:i:i]) {
+				return
+			}
+			data = data[i+len(sep):]
+		}
+	}
+}
+
+// TrimEach yields each value of s with all leading and trailing bytes contained in cutset removed, as subslices of the original values
+func TrimEach(s iter.Seq[[]byte], cutset string) iter.Seq[[]byte] {
+	return func(yield func([]byte) bool) {
+		for b := range s {
+			if !yield(bytes.Trim(b, cutset)) {
+				return
+			}
+		}
+	}
+}
+
+// ToUpperInPlace yields each value of s with its ASCII letters converted to upper case, modifying the original values in place rather than copying them. Non-ASCII bytes are left untouched
+func ToUpperInPlace(s iter.Seq[[]byte]) iter.Seq[[]byte] {
+	return flipCase(s, 'a', 'z')
+}
+
+// ToLowerInPlace yields each value of s with its ASCII letters converted to lower case in place, as with [ToUpperInPlace]
+func ToLowerInPlace(s iter.Seq[[]byte]) iter.Seq[[]byte] {
+	return flipCase(s, 'A', 'Z')
+}
+
+// flipCase switches the case of every ASCII letter between lo and hi inclusive in place
+func flipCase(s iter.Seq[[]byte], lo, hi byte) iter.Seq[[]byte] {
+	return func(yield func([]byte) bool) {
+		for b := range s {
+			for i, c := range b {
+				if lo <= c && c <= hi {
+					b[i] = c ^ 0x20
+				}
+			}
+			if !yield(b) {
+				return
+			}
+		}
+	}
+}
+
+// HashEach yields the digest of each value of s, computed with a single hash from newHash that is reset between values
+func HashEach(s iter.Seq[[]byte], newHash func() hash.Hash) iter.Seq[[]byte] {
+	return func(yield func([]byte) bool) {
+		h := newHash()
+		for b := range s {
+			h.Reset()
+			h.Write(b)
+			if !yield(h.Sum(nil)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package itbytes
+
+import (
+	"crypto/sha256"
+	"hash"
+	"hash/crc32"
+	"iter"
+	"slices"
+	"testing"
+
+	it "github.com/astonm/go-itertools"
+	"github.com/stretchr/testify/assert"
+)
+
+func strs(s iter.Seq[[]byte]) []string {
+	return slices.Collect(it.Map(func(b []byte) string { return string(b) }, s))
+}
+
+func byteSeq(vals ...string) iter.Seq[[]byte] {
+	return it.Map(func(s string) []byte { return []byte(s) }, it.NewSeq(vals...))
+}
+
+func TestSplitBytes(t *testing.T) {
+	assert.Equal(t, []string{"a", "b", "", "c"}, strs(SplitBytes([]byte("a,b,,c"), []byte(","))))
+	assert.Equal(t, []string{"a", "b"}, strs(SplitBytes([]byte("a\r\nb"), []byte("\r\n"))))
+	assert.Equal(t, []string{""}, strs(SplitBytes(nil, []byte(","))))
+	assert.Equal(t, []string{"x", "y"}, strs(SplitBytes([]byte("xy"), nil)))
+	assert.Equal(t, []string{"a"}, strs(it.Take(SplitBytes([]byte("a,b"), []byte(",")), 1)))
+
+	data := []byte("ab,cd")
+	for part := range SplitBytes(data, []byte(",")) {
+		_ = append(part, '!')
+	}
+	assert.Equal(t, "ab,cd", string(data))
+}
+
+func TestTrimEach(t *testing.T) {
+	assert.Equal(t, []string{"a", "b c", ""}, strs(TrimEach(byteSeq(" a\n", "\tb c ", "  "), " \t\n")))
+}
+
+func TestCaseInPlace(t *testing.T) {
+	assert.Equal(t, []string{"HELLO, WORLD", "ÄBC"}, strs(ToUpperInPlace(byteSeq("Hello, World", "Äbc"))))
+	assert.Equal(t, []string{"hello, world"}, strs(ToLowerInPlace(byteSeq("HeLLo, WORLD"))))
+
+	buf := []byte("abc")
+	for range ToUpperInPlace(it.NewSeq(buf)) {
+	}
+	assert.Equal(t, "ABC", string(buf))
+}
+
+func TestHashEach(t *testing.T) {
+	digests := slices.Collect(HashEach(byteSeq("a", "b", "a"), sha256.New))
+	want := sha256.Sum256([]byte("a"))
+	assert.Equal(t, want[:], digests[0])
+	assert.Equal(t, digests[0], digests[2])
+	assert.NotEqual(t, digests[0], digests[1])
+
+	crc := slices.Collect(HashEach(byteSeq("abc"), func() hash.Hash { return crc32.NewIEEE() }))
+	assert.Equal(t, []byte{0x35, 0x24, 0x41, 0xc2}, crc[0])
+}
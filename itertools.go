@@ -1,8 +1,15 @@
 package itertools
 
 import (
+	"cmp"
+	"fmt"
 	"iter"
+	"maps"
+	"math/big"
 	"slices"
+	"sync"
+
+	"github.com/astonm/go-itertools/tuples"
 )
 
 // NewSeq returns a sequence of values matching the sequence of values given as input
@@ -21,6 +28,22 @@ func FromSlice[T any](vals []T) iter.Seq[T] {
 	}
 }
 
+// FromMap returns a sequence of the entries of m, in the unspecified order of ranging over a map
+func FromMap[K comparable, V any](m map[K]V) iter.Seq2[K, V] {
+	return maps.All(m)
+}
+
+// FromMapSorted returns a sequence of the entries of m in ascending key order, for deterministic output. The keys are sorted each time the sequence is iterated
+func FromMapSorted[K cmp.Ordered, V any](m map[K]V) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, k := range slices.Sorted(maps.Keys(m)) {
+			if !yield(k, m[k]) {
+				return
+			}
+		}
+	}
+}
+
 // Enumerate takes an [iter.Seq] and returns an [iter.Seq2] pairing a zero-based index with each original sequence value
 func Enumerate[T any](s iter.Seq[T]) iter.Seq2[int, T] {
 	return func(yield func(int, T) bool) {
@@ -34,6 +57,12 @@ func Enumerate[T any](s iter.Seq[T]) iter.Seq2[int, T] {
 	}
 }
 
+// EnumerateNonZero is [Enumerate] yielding only the pairs whose value is not the zero value of T, for walking sparse vectors
+func EnumerateNonZero[T comparable](s iter.Seq[T]) iter.Seq2[int, T] {
+	var zero T
+	return Filter2(func(_ int, v T) bool { return v != zero }, Enumerate(s))
+}
+
 func Map[T any, U any](mapper func(T) U, s iter.Seq[T]) iter.Seq[U] {
 	return func(yield func(U) bool) {
 		for v := range s {
@@ -44,14 +73,51 @@ func Map[T any, U any](mapper func(T) U, s iter.Seq[T]) iter.Seq[U] {
 	}
 }
 
+// MapIndexed applies f to the zero-based index and value of each element of s, yielding the results
+func MapIndexed[T any, U any](f func(int, T) U, s iter.Seq[T]) iter.Seq[U] {
+	return StarMap(f, Enumerate(s))
+}
+
+// FlatMap applies f to each value of s and yields the values of each resulting sequence in turn, letting one input expand into zero or more outputs
+func FlatMap[T any, U any](f func(T) iter.Seq[U], s iter.Seq[T]) iter.Seq[U] {
+	return Flatten(Map(f, s))
+}
+
+// StarMap applies f to each key and value of s, yielding the results, like Python's itertools.starmap
+func StarMap[K any, V any, R any](f func(K, V) R, s iter.Seq2[K, V]) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		for k, v := range s {
+			if !yield(f(k, v)) {
+				return
+			}
+		}
+	}
+}
+
+// Map2 applies f to each key and value of s, yielding the resulting pairs
+func Map2[K any, V any, K2 any, V2 any](f func(K, V) (K2, V2), s iter.Seq2[K, V]) iter.Seq2[K2, V2] {
+	return func(yield func(K2, V2) bool) {
+		for k, v := range s {
+			if !yield(f(k, v)) {
+				return
+			}
+		}
+	}
+}
+
 func Take[T any](s iter.Seq[T], n int) iter.Seq[T] {
 	return func(yield func(T) bool) {
-		next, stop := iter.Pull(s)
-		defer stop()
+		if n <= 0 {
+			return
+		}
 
-		for i := 0; i < n; i++ {
-			v, ok := next()
-			if !ok || !yield(v) {
+		var i int
+		for v := range s {
+			if !yield(v) {
+				return
+			}
+			i++
+			if i == n {
 				return
 			}
 		}
@@ -59,9 +125,36 @@ func Take[T any](s iter.Seq[T], n int) iter.Seq[T] {
 }
 
 func Chain[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return ChainFromSeq(FromSlice(seqs))
+}
+
+// ChainFromSeq yields the values of each sequence produced by seqs in turn, pulling the next sequence only once the previous is exhausted, like Python's itertools.chain.from_iterable
+func ChainFromSeq[T any](seqs iter.Seq[iter.Seq[T]]) iter.Seq[T] {
 	return func(yield func(T) bool) {
-		for _, seq := range seqs {
-			seq(yield)
+		for seq := range seqs {
+			for v := range seq {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Flatten yields the values of each sequence of s in turn, undoing stages such as [GroupBy] that nest sequences. It is another name for [ChainFromSeq]
+func Flatten[T any](s iter.Seq[iter.Seq[T]]) iter.Seq[T] {
+	return ChainFromSeq(s)
+}
+
+// FlattenSlices yields the values of each slice of s in turn, undoing stages such as [Batched]
+func FlattenSlices[T any](s iter.Seq[[]T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for vals := range s {
+			for _, v := range vals {
+				if !yield(v) {
+					return
+				}
+			}
 		}
 	}
 }
@@ -76,6 +169,17 @@ func Count() iter.Seq[int] {
 	}
 }
 
+// CountFrom yields start, start+step, start+2*step, ... without end. A negative step counts down
+func CountFrom(start, step int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := start; ; i += step {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
 func Cycle[T any](s iter.Seq[T]) iter.Seq[T] {
 	return func(yield func(T) bool) {
 		for {
@@ -111,16 +215,30 @@ func Accumulate[T any](s iter.Seq[T], op func(T, T) T, initial T) iter.Seq[T] {
 	}
 }
 
-func Batched[T any](s iter.Seq[T], n int) iter.Seq[[]T] {
+// ScanPairs is [Accumulate] over an [iter.Seq2], yielding each key with the running aggregate after folding in its pair
+func ScanPairs[K any, V any, A any](s iter.Seq2[K, V], seed A, op func(A, K, V) A) iter.Seq2[K, A] {
+	return func(yield func(K, A) bool) {
+		acc := seed
+		for k, v := range s {
+			acc = op(acc, k, v)
+			if !yield(k, acc) {
+				return
+			}
+		}
+	}
+}
+
+func Batched[T any](s iter.Seq[T], n int, opts ...Option) iter.Seq[[]T] {
+	o := newOptions(opts)
 	return func(yield func([]T) bool) {
-		batch := make([]T, 0, n)
+		batch := reuse[T](o, nil, o.capacity(n))
 
 		for v := range s {
 			if len(batch) == n {
 				if !yield(batch) {
 					return
 				}
-				batch = make([]T, 0, n)
+				batch = reuse(o, batch, o.capacity(n))
 			}
 
 			batch = append(batch, v)
@@ -132,15 +250,187 @@ func Batched[T any](s iter.Seq[T], n int) iter.Seq[[]T] {
 	}
 }
 
+// BatchedSlice yields successive subslices of vals holding up to n values each, without copying. The views share memory with vals but are capped at their length, so appending to one never overwrites the next
+func BatchedSlice[T any](vals []T, n int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if n <= 0 {
+			return
+		}
+		for i := 0; i < len(vals); i += n {
+			end := min(i+n, len(vals))
+			if !yield(vals[i:end:end]) {
+				return
+			}
+		}
+	}
+}
+
+// WindowedSlice yields every subslice of vals holding n consecutive values, starting step values apart, without copying. Only full windows are yielded, and each is capped at its length like those of [BatchedSlice]
+func WindowedSlice[T any](vals []T, n, step int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if n <= 0 || step <= 0 {
+			return
+		}
+		for i := 0; i+n <= len(vals); i += step {
+			if !yield(vals[i : i+n : i+n]) {
+				return
+			}
+		}
+	}
+}
+
+// Batched2 groups the pairs of s into maps of up to n distinct keys. A key repeated within a batch keeps its latest value
+func Batched2[K comparable, V any](s iter.Seq2[K, V], n int) iter.Seq[map[K]V] {
+	return func(yield func(map[K]V) bool) {
+		batch := make(map[K]V, n)
+
+		for k, v := range s {
+			if _, ok := batch[k]; !ok && len(batch) == n {
+				if !yield(batch) {
+					return
+				}
+				batch = make(map[K]V, n)
+			}
+
+			batch[k] = v
+		}
+
+		if len(batch) > 0 {
+			yield(batch)
+		}
+	}
+}
+
+// BatchedPairs groups the pairs of s into slices of up to n [tuples.T2] values, preserving their order
+func BatchedPairs[K any, V any](s iter.Seq2[K, V], n int) iter.Seq[[]tuples.T2[K, V]] {
+	return func(yield func([]tuples.T2[K, V]) bool) {
+		batch := make([]tuples.T2[K, V], 0, n)
+
+		for k, v := range s {
+			if len(batch) == n {
+				if !yield(batch) {
+					return
+				}
+				batch = make([]tuples.T2[K, V], 0, n)
+			}
+
+			batch = append(batch, tuples.Of2(k, v))
+		}
+
+		if len(batch) > 0 {
+			yield(batch)
+		}
+	}
+}
+
+// BatchedBySerializedSize groups s into batches holding at most maxItems values whose sizes, as reported by sizeOf, total at most maxBytes. A value larger than maxBytes on its own is emitted as a single-item batch, and a maxItems of zero or less leaves the item count uncapped
+func BatchedBySerializedSize[T any](s iter.Seq[T], sizeOf func(T) int, maxBytes int, maxItems int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		var batch []T
+		var batchBytes int
+
+		for v := range s {
+			size := sizeOf(v)
+			full := maxItems > 0 && len(batch) == maxItems
+			if len(batch) > 0 && (full || batchBytes+size > maxBytes) {
+				if !yield(batch) {
+					return
+				}
+				batch = nil
+				batchBytes = 0
+			}
+
+			batch = append(batch, v)
+			batchBytes += size
+		}
+
+		if len(batch) > 0 {
+			yield(batch)
+		}
+	}
+}
+
+// ChunkWhile splits s into runs of consecutive values, starting a new run whenever together(prev, next) is false for a pair of adjacent values. Run sizes can be capped with [WithMaxGroupSize]
+func ChunkWhile[T any](s iter.Seq[T], together func(prev, next T) bool, opts ...GroupOption) iter.Seq[[]T] {
+	return chunkWhile(s, together, newGroupOptions(opts), nil)
+}
+
+// TryChunkWhile is [ChunkWhile] ending with an error wrapping [ErrGroupOverflow] when a run overflows under [ErrorOnOverflow]
+func TryChunkWhile[T any](s iter.Seq[T], together func(prev, next T) bool, opts ...GroupOption) iter.Seq2[[]T, error] {
+	o := newGroupOptions(opts)
+	return func(yield func([]T, error) bool) {
+		var overflowed bool
+		for chunk := range chunkWhile(s, together, o, &overflowed) {
+			if !yield(chunk, nil) {
+				return
+			}
+		}
+		if overflowed {
+			yield(nil, o.overflowError())
+		}
+	}
+}
+
+// chunkWhile implements [ChunkWhile], setting overflowed if it stops at a run overflowing under [ErrorOnOverflow]
+func chunkWhile[T any](s iter.Seq[T], together func(prev, next T) bool, o groupOptions, overflowed *bool) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		var chunk []T
+		// dropping is set once a capped run has overflowed, discarding the rest of it
+		var dropping bool
+		var last T
+		for v := range s {
+			if dropping {
+				if together(last, v) {
+					last = v
+					continue
+				}
+				dropping = false
+			}
+
+			if len(chunk) > 0 && !together(chunk[len(chunk)-1], v) {
+				if !yield(chunk) {
+					return
+				}
+				chunk = nil
+			} else if o.overflows(len(chunk)) {
+				if o.overflow == ErrorOnOverflow {
+					if overflowed != nil {
+						*overflowed = true
+					}
+					return
+				}
+				if o.overflow == DropOverflow {
+					dropping, last = true, v
+					continue
+				}
+				if !yield(chunk) {
+					return
+				}
+				chunk = nil
+			}
+			chunk = append(chunk, v)
+		}
+
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
 func pick[T any](vals []T, indices []int) []T {
-	out := make([]T, 0, len(indices))
+	return pickInto(make([]T, 0, len(indices)), vals, indices)
+}
+
+// pickInto appends the values of vals at indices to dst
+func pickInto[T any](dst []T, vals []T, indices []int) []T {
 	for _, i := range indices {
-		out = append(out, vals[i])
+		dst = append(dst, vals[i])
 	}
-	return out
+	return dst
 }
 
-func Combinations[T any](vals []T, r int) iter.Seq[[]T] {
+func Combinations[T any](vals []T, r int, opts ...Option) iter.Seq[[]T] {
+	o := newOptions(opts)
 	return func(yield func([]T) bool) {
 		if r > len(vals) {
 			return
@@ -151,33 +441,105 @@ func Combinations[T any](vals []T, r int) iter.Seq[[]T] {
 			indices = append(indices, i)
 		}
 
-		yield(pick(vals, indices))
-
+		var buf []T
 		for {
-			var i int
-			var found bool
-			for i = r - 1; i >= 0; i-- {
-				if indices[i] != i+len(vals)-r {
-					found = true
-					break
-				}
+			buf = pickInto(reuse(o, buf, r), vals, indices)
+			if !yield(buf) {
+				return
 			}
-
-			if !found {
+			if !NextCombination(indices, len(vals)) {
 				return
 			}
+		}
+	}
+}
 
-			indices[i]++
-			for j := i + 1; j < r; j++ {
-				indices[j] = indices[j-1] + 1
+// binomial returns the number of ways to choose k of n items as a [big.Int], since the count quickly overflows an int
+func binomial(n, k int) *big.Int {
+	if k < 0 || k > n {
+		return new(big.Int)
+	}
+	return new(big.Int).Binomial(int64(n), int64(k))
+}
+
+// unrankCombination returns the indices of the combination of r of n items at position rank in lexicographic order
+func unrankCombination(n, r int, rank *big.Int) []int {
+	indices := make([]int, r)
+	k, c := new(big.Int).Set(rank), 0
+	for i := range r {
+		for {
+			count := binomial(n-c-1, r-i-1)
+			if k.Cmp(count) < 0 {
+				break
 			}
+			k.Sub(k, count)
+			c++
+		}
+		indices[i] = c
+		c++
+	}
+	return indices
+}
+
+// NextCombination advances idx, an increasing selection of indices below n, in place to its lexicographic successor. It returns false, leaving idx unchanged, if idx is already the last combination
+func NextCombination(idx []int, n int) bool {
+	r := len(idx)
+	i := r - 1
+	for i >= 0 && idx[i] == i+n-r {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+
+	idx[i]++
+	for j := i + 1; j < r; j++ {
+		idx[j] = idx[j-1] + 1
+	}
+	return true
+}
+
+// CombinationsSharded yields the shard-th of numShards contiguous, near-equal portions of the combinations produced by [Combinations], jumping directly to the start of its portion. Running every shard from 0 to numShards-1 covers each combination exactly once. Like [Combinations], nothing is yielded when r is negative or exceeds len(vals). It panics unless 0 <= shard < numShards
+func CombinationsSharded[T any](vals []T, r, shard, numShards int) iter.Seq[[]T] {
+	if numShards <= 0 || shard < 0 || shard >= numShards {
+		panic(fmt.Sprintf("itertools: CombinationsSharded shard %d out of range for %d shards", shard, numShards))
+	}
+
+	return func(yield func([]T) bool) {
+		n := len(vals)
+		if r < 0 || r > n {
+			return
+		}
+
+		total := binomial(n, r)
+		bound := func(i int) *big.Int {
+			b := new(big.Int).Mul(total, big.NewInt(int64(i)))
+			return b.Quo(b, big.NewInt(int64(numShards)))
+		}
+		start, end := bound(shard), bound(shard+1)
+		if start.Cmp(end) >= 0 {
+			return
+		}
 
-			yield(pick(vals, indices))
+		indices := unrankCombination(n, r, start)
+		var stop []int
+		if end.Cmp(total) < 0 {
+			stop = unrankCombination(n, r, end)
+		}
+
+		for stop == nil || !slices.Equal(indices, stop) {
+			if !yield(pick(vals, indices)) {
+				return
+			}
+			if !NextCombination(indices, n) {
+				return
+			}
 		}
 	}
 }
 
-func CombinationsWithReplacement[T any](vals []T, r int) iter.Seq[[]T] {
+func CombinationsWithReplacement[T any](vals []T, r int, opts ...Option) iter.Seq[[]T] {
+	o := newOptions(opts)
 	return func(yield func([]T) bool) {
 		if len(vals) == 0 && r == 0 {
 			return
@@ -185,7 +547,10 @@ func CombinationsWithReplacement[T any](vals []T, r int) iter.Seq[[]T] {
 
 		indices := make([]int, r)
 
-		yield(pick(vals, indices))
+		buf := pickInto(reuse[T](o, nil, r), vals, indices)
+		if !yield(buf) {
+			return
+		}
 		for {
 			var i int
 			var found bool
@@ -205,7 +570,10 @@ func CombinationsWithReplacement[T any](vals []T, r int) iter.Seq[[]T] {
 				indices[j] = nextIndex
 			}
 
-			yield(pick(vals, indices))
+			buf = pickInto(reuse(o, buf, r), vals, indices)
+			if !yield(buf) {
+				return
+			}
 		}
 	}
 }
@@ -243,6 +611,41 @@ func DropWhile[T any](pred func(T) bool, s iter.Seq[T]) iter.Seq[T] {
 	}
 }
 
+// DropWhile2 is [DropWhile] for an [iter.Seq2], skipping pairs for as long as pred(k, v) holds
+func DropWhile2[K any, V any](pred func(K, V) bool, s iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var shouldYield bool
+		for k, v := range s {
+			if !shouldYield && !pred(k, v) {
+				shouldYield = true
+			}
+			if shouldYield {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Filter yields only the values of s for which pred returns true
+func Filter[T any](pred func(T) bool, s iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s {
+			if pred(v) {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FilterIndexed yields only the values of s for which pred returns true when given their zero-based index and the value
+func FilterIndexed[T any](pred func(int, T) bool, s iter.Seq[T]) iter.Seq[T] {
+	return Values(Filter2(pred, Enumerate(s)))
+}
+
 func FilterFalse[T any](pred func(T) bool, s iter.Seq[T]) iter.Seq[T] {
 	return func(yield func(T) bool) {
 		for v := range s {
@@ -255,65 +658,130 @@ func FilterFalse[T any](pred func(T) bool, s iter.Seq[T]) iter.Seq[T] {
 	}
 }
 
-func GroupBy[T comparable](s iter.Seq[T]) iter.Seq2[T, iter.Seq[T]] {
-	return func(yield func(T, iter.Seq[T]) bool) {
+// GroupBy yields each run of consecutive equal values of s, see [GroupByFunc]
+func GroupBy[T comparable](s iter.Seq[T], opts ...GroupOption) iter.Seq2[T, iter.Seq[T]] {
+	return GroupByFunc(s, func(v T) T { return v }, opts...)
+}
+
+// GroupByFunc yields each run of consecutive values of s sharing the same key, like Python's itertools.groupby with a key function. Each group shares the underlying iterator with the outer sequence, so a group is only valid until the outer iteration advances. Group sizes can be capped with [WithMaxGroupSize]
+func GroupByFunc[T any, K comparable](s iter.Seq[T], key func(T) K, opts ...GroupOption) iter.Seq2[K, iter.Seq[T]] {
+	return groupByFunc(s, key, newGroupOptions(opts), nil)
+}
+
+// TryGroupBy is [GroupBy] reporting overflow under [ErrorOnOverflow], see [TryGroupByFunc]
+func TryGroupBy[T comparable](s iter.Seq[T], opts ...GroupOption) iter.Seq2[T, iter.Seq2[T, error]] {
+	return TryGroupByFunc(s, func(v T) T { return v }, opts...)
+}
+
+// TryGroupByFunc is [GroupByFunc] reporting overflow under [ErrorOnOverflow]: the group that overflows ends with an error wrapping [ErrGroupOverflow] and no further groups follow. If that group was not read to its end, the error arrives in one extra group holding only the error
+func TryGroupByFunc[T any, K comparable](s iter.Seq[T], key func(T) K, opts ...GroupOption) iter.Seq2[K, iter.Seq2[T, error]] {
+	o := newGroupOptions(opts)
+	return func(yield func(K, iter.Seq2[T, error]) bool) {
+		var overflowed, reported bool
+		report := func(yield func(T, error) bool) {
+			if overflowed && !reported {
+				reported = true
+				var zero T
+				yield(zero, o.overflowError())
+			}
+		}
+
+		var lastKey K
+		for k, g := range groupByFunc(s, key, o, &overflowed) {
+			lastKey = k
+			group := func(yield func(T, error) bool) {
+				for v := range g {
+					if !yield(v, nil) {
+						return
+					}
+				}
+				report(yield)
+			}
+			if !yield(k, group) {
+				return
+			}
+		}
+
+		if overflowed && !reported {
+			yield(lastKey, report)
+		}
+	}
+}
+
+// groupByFunc implements [GroupByFunc], setting overflowed if it stops at a group overflowing under [ErrorOnOverflow]
+func groupByFunc[T any, K comparable](s iter.Seq[T], key func(T) K, o groupOptions, overflowed *bool) iter.Seq2[K, iter.Seq[T]] {
+	return func(yield func(K, iter.Seq[T]) bool) {
 		next, stop := iter.Pull(s)
 		defer stop()
 
 		var current T
+		var currentKey K
 		var ok bool
+		advance := func() {
+			current, ok = next()
+			if ok {
+				currentKey = key(current)
+			}
+		}
 
-		pullGroup := func(groupValue T) iter.Seq[T] {
-			return func(yield func(T) bool) {
-				if !yield(groupValue) {
-					return
+		// generation identifies the active group, so stale groups yield nothing
+		var generation int
+		// size counts the values of the active group read so far
+		var size int
+		// failed is set once a group overflows under ErrorOnOverflow, ending the grouping
+		var failed bool
+
+		// inGroup reports whether the current value continues the group, applying the size cap
+		inGroup := func(groupKey K) bool {
+			if failed || !ok || currentKey != groupKey {
+				return false
+			}
+			if !o.overflows(size) {
+				return true
+			}
+			if o.overflow == ErrorOnOverflow {
+				failed = true
+				if overflowed != nil {
+					*overflowed = true
 				}
+			}
+			if o.overflow == DropOverflow {
+				for ok && currentKey == groupKey {
+					advance()
+				}
+			}
+			return false
+		}
 
-				var v T
-				for {
-					v, ok = next()
-					if !ok {
-						return
-					}
-
-					if v != groupValue {
-						current = v
-						return
-					}
-
+		advance()
+		for ok {
+			groupKey := currentKey
+			generation++
+			groupGeneration := generation
+			size = 0
+
+			group := func(yield func(T) bool) {
+				for generation == groupGeneration && inGroup(groupKey) {
+					v := current
+					size++
+					advance()
 					if !yield(v) {
 						return
 					}
 				}
 			}
-		}
 
-		current, ok = next()
-		if !ok {
-			return
-		}
-
-		for {
-			group := pullGroup(current)
-			if !ok {
+			if !yield(groupKey, group) {
 				return
 			}
 
-			if !yield(current, group) {
-				return
+			// skip whatever the consumer left of the group before moving to the next one
+			for inGroup(groupKey) {
+				size++
+				advance()
 			}
-
-			// exhaust remaining group items before moving to next group
-			var v T
-			for {
-				v, ok = next()
-				if !ok {
-					return
-				}
-				if v != current {
-					current = v
-					return
-				}
+			if failed {
+				return
 			}
 		}
 	}
@@ -355,7 +823,27 @@ func Pairwise[T any](s iter.Seq[T]) iter.Seq2[T, T] {
 	}
 }
 
-func Permutations[T any](vals []T, r int) iter.Seq[[]T] {
+// NextPermutation rearranges p in place into its lexicographic successor. It returns false, leaving p unchanged, if p is already the last permutation, i.e. in descending order
+func NextPermutation(p []int) bool {
+	i := len(p) - 2
+	for i >= 0 && p[i] >= p[i+1] {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+
+	j := len(p) - 1
+	for p[j] <= p[i] {
+		j--
+	}
+	p[i], p[j] = p[j], p[i]
+	slices.Reverse(p[i+1:])
+	return true
+}
+
+func Permutations[T any](vals []T, r int, opts ...Option) iter.Seq[[]T] {
+	o := newOptions(opts)
 	return func(yield func([]T) bool) {
 		n := len(vals)
 		if r > n {
@@ -367,87 +855,165 @@ func Permutations[T any](vals []T, r int) iter.Seq[[]T] {
 			indices = append(indices, i)
 		}
 
-		cycles := make([]int, 0, r)
-		for i := n; i > n-r; i-- {
-			cycles = append(cycles, i)
+		var buf []T
+		for {
+			buf = pickInto(reuse(o, buf, r), vals, indices[:r])
+			if !yield(buf) {
+				return
+			}
+
+			// the unused tail is ascending; reversing it makes this the last
+			// permutation sharing the current prefix, so the next one changes it
+			slices.Reverse(indices[r:])
+			if !NextPermutation(indices) {
+				return
+			}
 		}
+	}
+}
 
-		yield(pick(vals, indices[:r]))
+// PermutationsPruned yields the full-length permutations of vals in the same order as [Permutations], but only extends prefixes for which accept returns true, pruning every permutation that begins with a rejected prefix
+func PermutationsPruned[T any](vals []T, accept func(prefix []T) bool) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		n := len(vals)
+		used := make([]bool, n)
+		prefix := make([]T, 0, n)
 
-		if n == 0 {
-			return
-		}
+		var extend func() bool
+		extend = func() bool {
+			if len(prefix) == n {
+				return yield(slices.Clone(prefix))
+			}
 
-		for {
-			var i int
-			var found bool
-			for i = r - 1; i >= 0; i-- {
-				cycles[i]--
-				if cycles[i] == 0 {
-					// move to end and reset
-					ind := indices[i]
-					indices = append(append(indices[:i], indices[i+1:]...), ind)
-					cycles[i] = n - i
-				} else {
-					j := n - cycles[i]
-					indices[i], indices[j] = indices[j], indices[i]
-
-					yield(pick(vals, indices[:r]))
-					found = true
-					break
+			for i, v := range vals {
+				if used[i] {
+					continue
 				}
-			}
 
-			if !found {
-				return
+				used[i] = true
+				prefix = append(prefix, v)
+				if accept(prefix) && !extend() {
+					return false
+				}
+				prefix = prefix[:len(prefix)-1]
+				used[i] = false
 			}
+			return true
 		}
+
+		extend()
 	}
 }
 
+// Product yields the cartesian product of the slices in pool. Its variadic pool leaves no room for trailing options, so it is the one slice-producing function that takes none; use [ProductWith] to pass them
 func Product[T any](pool ...[]T) iter.Seq[[]T] {
-	return func(yield func([]T) bool) {
-		n := len(pool)
+	return product(pool, nil)
+}
 
-		maxIndices := make([]int, n)
-		for i := range n {
-			maxIndices[i] = len(pool[i]) - 1
-		}
+// ProductWith is [Product] with [Option] values tuning how the yielded slices are allocated. It exists only because Product's variadic signature cannot also accept options, and is the single exception to functions taking their options directly
+func ProductWith[T any](pool [][]T, opts ...Option) iter.Seq[[]T] {
+	return product(pool, opts)
+}
 
-		indices := make([]int, n)
+func product[T any](pool [][]T, opts []Option) iter.Seq[[]T] {
+	o := newOptions(opts)
+	dims := make([]int, len(pool))
+	for i, vals := range pool {
+		dims[i] = len(vals)
+	}
 
-		for {
-			prod := make([]T, n)
-			for i := range n {
-				prod[i] = pool[i][indices[i]]
+	return func(yield func([]T) bool) {
+		var prod []T
+		for idx := range odometer(dims...) {
+			prod = reuse(o, prod, len(pool))
+			for i, j := range idx {
+				prod = append(prod, pool[i][j])
 			}
-
 			if !yield(prod) {
 				return
 			}
+		}
+	}
+}
 
-			if slices.Equal(indices, maxIndices) {
-				return
+func ProductRepeat[T any](vals []T, repeat int, opts ...Option) iter.Seq[[]T] {
+	inputs := make([][]T, repeat)
+	for i := range repeat {
+		inputs[i] = vals
+	}
+	return product(inputs, opts)
+}
+
+// ProductPruned yields the same tuples as [Product] over pool, but skips every tuple beginning with a prefix for which prune returns true, without generating the rest of that subtree
+func ProductPruned[T any](pool [][]T, prune func(prefix []T) bool) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		n := len(pool)
+		prefix := make([]T, 0, n)
+		indices := make([]int, 0, n)
+
+		for {
+			depth := len(prefix)
+			if depth == n {
+				if !yield(slices.Clone(prefix)) {
+					return
+				}
+			} else if len(pool[depth]) > 0 {
+				// descend into the first child
+				prefix = append(prefix, pool[depth][0])
+				indices = append(indices, 0)
+				if !prune(prefix) {
+					continue
+				}
 			}
 
-			for i := n - 1; i >= 0; i-- {
-				if indices[i] < maxIndices[i] {
-					indices[i]++
-					break
-				} else {
-					indices[i] = 0
+			// advance to the next sibling, backtracking as needed
+			for {
+				depth = len(prefix)
+				if depth == 0 {
+					return
 				}
+
+				i := indices[depth-1] + 1
+				if i < len(pool[depth-1]) {
+					indices[depth-1] = i
+					prefix[depth-1] = pool[depth-1][i]
+					if !prune(prefix) {
+						break
+					}
+					continue
+				}
+
+				prefix = prefix[:depth-1]
+				indices = indices[:depth-1]
 			}
 		}
 	}
 }
 
-func ProductRepeat[T any](vals []T, repeat int) iter.Seq[[]T] {
-	inputs := make([][]T, repeat)
-	for i := range repeat {
-		inputs[i] = vals
+// Product2 yields every pairing of a value from as with a value from bs, in the same order as [Product]
+func Product2[A any, B any](as []A, bs []B) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		for _, a := range as {
+			for _, b := range bs {
+				if !yield(a, b) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Product3 yields a [tuples.T3] for every combination of one value from each of as, bs and cs, in the same order as [Product]
+func Product3[A any, B any, C any](as []A, bs []B, cs []C) iter.Seq[tuples.T3[A, B, C]] {
+	return func(yield func(tuples.T3[A, B, C]) bool) {
+		for a, b := range Product2(as, bs) {
+			for _, c := range cs {
+				if !yield(tuples.Of3(a, b, c)) {
+					return
+				}
+			}
+		}
 	}
-	return Product(inputs...)
 }
 
 func TakeWhile[T any](pred func(T) bool, s iter.Seq[T]) iter.Seq[T] {
@@ -460,8 +1026,160 @@ func TakeWhile[T any](pred func(T) bool, s iter.Seq[T]) iter.Seq[T] {
 	}
 }
 
+// TakeWhile2 is [TakeWhile] for an [iter.Seq2], yielding pairs only for as long as pred(k, v) holds
+func TakeWhile2[K any, V any](pred func(K, V) bool, s iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range s {
+			if !pred(k, v) || !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// DefaultIfEmpty yields the values of s, or the fallback values if s turns out to be empty
+func DefaultIfEmpty[T any](s iter.Seq[T], fallback ...T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		empty := true
+		for v := range s {
+			empty = false
+			if !yield(v) {
+				return
+			}
+		}
+
+		if empty {
+			for _, v := range fallback {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// OnEmpty yields the values of s, calling fn if s is exhausted without having produced any
+func OnEmpty[T any](s iter.Seq[T], fn func()) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		empty := true
+		for v := range s {
+			empty = false
+			if !yield(v) {
+				return
+			}
+		}
+
+		if empty {
+			fn()
+		}
+	}
+}
+
+// Tee splits s into two independent sequences, buffering values until both have consumed them, so single-use sources can be read twice. See [TeeN]
 func Tee[T any](s iter.Seq[T]) (iter.Seq[T], iter.Seq[T]) {
-	return s, s
+	seqs := TeeN(s, 2)
+	return seqs[0], seqs[1]
+}
+
+// teeBuffer holds the values of a shared source that some tee branch has yet to consume
+type teeBuffer[T any] struct {
+	mu     sync.Mutex
+	src    iter.Seq[T]
+	next   func() (T, bool)
+	stop   func()
+	done   bool
+	buf    []T
+	base   int   // absolute position of buf[0]
+	pos    []int // absolute position of each branch, or -1 once it has closed
+	closed int
+}
+
+// get returns the value at branch i's position and advances it
+func (t *teeBuffer[T]) get(i int) (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var zero T
+	offset := t.pos[i] - t.base
+	if offset == len(t.buf) {
+		if t.done {
+			return zero, false
+		}
+		if t.next == nil {
+			t.next, t.stop = iter.Pull(t.src)
+		}
+
+		v, ok := t.next()
+		if !ok {
+			t.done = true
+			t.stop()
+			return zero, false
+		}
+		t.buf = append(t.buf, v)
+	}
+
+	v := t.buf[offset]
+	t.pos[i]++
+	t.trim()
+	return v, true
+}
+
+// close marks branch i finished, stopping the source once every branch is
+func (t *teeBuffer[T]) close(i int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pos[i] = -1
+	t.closed++
+	if t.closed == len(t.pos) && t.stop != nil && !t.done {
+		t.done = true
+		t.stop()
+	}
+	t.trim()
+}
+
+// trim drops buffered values that every open branch has moved past
+func (t *teeBuffer[T]) trim() {
+	lowest := -1
+	for _, p := range t.pos {
+		if p >= 0 && (lowest < 0 || p < lowest) {
+			lowest = p
+		}
+	}
+	if lowest < 0 {
+		lowest = t.base + len(t.buf)
+	}
+
+	if n := lowest - t.base; n > 0 {
+		clear(t.buf[:n])
+		t.buf = t.buf[n:]
+		t.base = lowest
+	}
+}
+
+// TeeN splits s into n independent sequences which may each be ranged over once, at their own pace. Values are buffered until every sequence has consumed them or finished, and s is stopped once all of them have finished
+func TeeN[T any](s iter.Seq[T], n int) []iter.Seq[T] {
+	t := &teeBuffer[T]{src: s, pos: make([]int, n)}
+
+	seqs := make([]iter.Seq[T], n)
+	for i := range seqs {
+		var used bool
+		seqs[i] = func(yield func(T) bool) {
+			if used {
+				return
+			}
+			used = true
+			defer t.close(i)
+
+			for {
+				v, ok := t.get(i)
+				if !ok || !yield(v) {
+					return
+				}
+			}
+		}
+	}
+	return seqs
 }
 
 func Zip[T any, U any](s0 iter.Seq[T], s1 iter.Seq[U]) iter.Seq2[T, U] {
@@ -487,6 +1205,130 @@ func Zip[T any, U any](s0 iter.Seq[T], s1 iter.Seq[U]) iter.Seq2[T, U] {
 	}
 }
 
+// ZipPairs is [Zip] yielding each pair as a [tuples.T2], for when pairs must be stored or passed on as single values
+func ZipPairs[T any, U any](s0 iter.Seq[T], s1 iter.Seq[U]) iter.Seq[tuples.T2[T, U]] {
+	return ZipWith(tuples.Of2[T, U], s0, s1)
+}
+
+// Zip3 yields a [tuples.T3] of corresponding values of s0, s1 and s2, stopping when any is exhausted
+func Zip3[A any, B any, C any](s0 iter.Seq[A], s1 iter.Seq[B], s2 iter.Seq[C]) iter.Seq[tuples.T3[A, B, C]] {
+	return ZipWith(func(p tuples.T2[A, B], c C) tuples.T3[A, B, C] {
+		return tuples.Of3(p.V1, p.V2, c)
+	}, ZipPairs(s0, s1), s2)
+}
+
+// ZipWith yields f applied to corresponding values of a and b, stopping when either is exhausted
+func ZipWith[T any, U any, V any](f func(T, U) V, a iter.Seq[T], b iter.Seq[U]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for x, y := range Zip(a, b) {
+			if !yield(f(x, y)) {
+				return
+			}
+		}
+	}
+}
+
+// Unzip splits s into a sequence of its keys and a sequence of its values, the inverse of [Zip]. Each may be ranged over once, at its own pace, with pairs buffered until both have consumed them as with [Tee]
+func Unzip[K any, V any](s iter.Seq2[K, V]) (iter.Seq[K], iter.Seq[V]) {
+	var pairs iter.Seq[tuples.T2[K, V]] = func(yield func(tuples.T2[K, V]) bool) {
+		for k, v := range s {
+			if !yield(tuples.Of2(k, v)) {
+				return
+			}
+		}
+	}
+
+	keys, vals := Tee(pairs)
+	return Map(func(p tuples.T2[K, V]) K { return p.V1 }, keys), Map(func(p tuples.T2[K, V]) V { return p.V2 }, vals)
+}
+
+// ZipLongest pairs up the values of s0 and s1 until both are exhausted, substituting fillT or fillU for whichever sequence runs out first
+func ZipLongest[T any, U any](s0 iter.Seq[T], s1 iter.Seq[U], fillT T, fillU U) iter.Seq2[T, U] {
+	return func(yield func(T, U) bool) {
+		next0, stop0 := iter.Pull(s0)
+		next1, stop1 := iter.Pull(s1)
+
+		defer stop0()
+		defer stop1()
+
+		for {
+			v0, ok0 := next0()
+			v1, ok1 := next1()
+
+			if !ok0 && !ok1 {
+				return
+			}
+			if !ok0 {
+				v0 = fillT
+			}
+			if !ok1 {
+				v1 = fillU
+			}
+
+			if !yield(v0, v1) {
+				return
+			}
+		}
+	}
+}
+
+// ZipLongestN yields a slice holding the next value of each sequence until all are exhausted, substituting fill for sequences that have run out
+func ZipLongestN[T any](fill T, seqs ...iter.Seq[T]) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		nexts := make([]func() (T, bool), len(seqs))
+		for i, s := range seqs {
+			next, stop := iter.Pull(s)
+			defer stop()
+			nexts[i] = next
+		}
+
+		for {
+			var live bool
+			vals := make([]T, len(seqs))
+			for i, next := range nexts {
+				v, ok := next()
+				if !ok {
+					v = fill
+				}
+				vals[i] = v
+				live = live || ok
+			}
+
+			if !live || !yield(vals) {
+				return
+			}
+		}
+	}
+}
+
+// ZipByIndex merge-joins two index-tagged sequences sorted by ascending index, yielding a [tuples.T2] for each index present in both
+func ZipByIndex[T any, U any](a iter.Seq2[int, T], b iter.Seq2[int, U]) iter.Seq2[int, tuples.T2[T, U]] {
+	return func(yield func(int, tuples.T2[T, U]) bool) {
+		nextA, stopA := iter.Pull2(a)
+		nextB, stopB := iter.Pull2(b)
+
+		defer stopA()
+		defer stopB()
+
+		ia, va, okA := nextA()
+		ib, vb, okB := nextB()
+		for okA && okB {
+			switch {
+			case ia < ib:
+				ia, va, okA = nextA()
+			case ia > ib:
+				ib, vb, okB = nextB()
+			default:
+				if !yield(ia, tuples.Of2(va, vb)) {
+					return
+				}
+				ia, va, okA = nextA()
+				ib, vb, okB = nextB()
+			}
+		}
+	}
+}
+
 func PullZip3[T any, U any, V any](s0 iter.Seq[T], s1 iter.Seq[U], s2 iter.Seq[V]) (func() (T, U, V, bool), func()) {
 	next0, stop0 := iter.Pull(s0)
 	next1, stop1 := iter.Pull(s1)
@@ -2,11 +2,25 @@ package itertools
 
 import (
 	"iter"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/astonm/go-itertools/tuples"
 	"github.com/stretchr/testify/assert"
 )
 
+func toSlice2[K any, V any](s iter.Seq2[K, V]) ([]K, []V) {
+	keys := make([]K, 0)
+	vals := make([]V, 0)
+	for k, v := range s {
+		keys = append(keys, k)
+		vals = append(vals, v)
+	}
+	return keys, vals
+}
+
 func toSlice[T any](s iter.Seq[T]) []T {
 	maxLen := 20
 	next, _ := iter.Pull(s)
@@ -34,6 +48,30 @@ func TestNewSeq(t *testing.T) {
 	assertSequenceMatch(t, NewSeq(1, 2, 3), []int{1, 2, 3})
 }
 
+func TestFromMap(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	got := map[string]int{}
+	for k, v := range FromMap(m) {
+		got[k] = v
+	}
+	assert.Equal(t, m, got)
+
+	keys, _ := toSlice2(Take2(FromMap(m), 2))
+	assert.Len(t, keys, 2)
+}
+
+func TestFromMapSorted(t *testing.T) {
+	keys, vals := toSlice2(FromMapSorted(map[string]int{"c": 3, "a": 1, "b": 2}))
+	assert.Equal(t, []string{"a", "b", "c"}, keys)
+	assert.Equal(t, []int{1, 2, 3}, vals)
+
+	keys, _ = toSlice2(Take2(FromMapSorted(map[string]int{"c": 3, "a": 1, "b": 2}), 1))
+	assert.Equal(t, []string{"a"}, keys)
+
+	keys, _ = toSlice2(FromMapSorted(map[string]int(nil)))
+	assert.Empty(t, keys)
+}
+
 func TestEnumerate(t *testing.T) {
 	keys := make([]int, 0)
 	vals := make([]int, 0)
@@ -44,9 +82,23 @@ func TestEnumerate(t *testing.T) {
 	assert.Equal(t, keys, vals)
 }
 
+func TestEnumerateNonZero(t *testing.T) {
+	keys, vals := toSlice2(EnumerateNonZero(NewSeq(0, 3, 0, 0, -1, 0)))
+	assert.Equal(t, []int{1, 4}, keys)
+	assert.Equal(t, []int{3, -1}, vals)
+
+	keys, _ = toSlice2(EnumerateNonZero(NewSeq("", "")))
+	assert.Empty(t, keys)
+}
+
 func TestTake(t *testing.T) {
 	assertSequenceMatch(t, Take(NewSeq(1, 2, 3), 0), []int{})
 	assertSequenceMatch(t, Take(NewSeq(1, 2, 3, 4, 5, 6), 3), []int{1, 2, 3})
+
+	var produced int
+	counted := Map(func(x int) int { produced++; return x }, Count())
+	assertSequenceMatch(t, Take(counted, 3), []int{0, 1, 2})
+	assert.Equal(t, 3, produced)
 }
 
 func TestChain(t *testing.T) {
@@ -54,12 +106,64 @@ func TestChain(t *testing.T) {
 		Chain(NewSeq(1, 2, 3), NewSeq(4, 5, 6)),
 		[]int{1, 2, 3, 4, 5, 6},
 	)
+
+	var ranLater bool
+	later := OnClose(NewSeq(4), func() { ranLater = true })
+	for v := range Chain(NewSeq(1, 2, 3), later) {
+		if v == 2 {
+			break
+		}
+	}
+	assert.False(t, ranLater)
+}
+
+func TestChainFromSeq(t *testing.T) {
+	ranges := Map(func(n int) iter.Seq[int] { return Take(Count(), n) }, Count())
+	assertSequenceMatch(t, Take(ChainFromSeq(ranges), 7), []int{0, 0, 1, 0, 1, 2, 0})
+}
+
+func TestMapIndexed(t *testing.T) {
+	label := func(i int, s string) string { return strconv.Itoa(i) + ":" + s }
+	assertSequenceMatch(t, MapIndexed(label, NewSeq("a", "b", "c")), []string{"0:a", "1:b", "2:c"})
+	assertSequenceMatch(t, Take(MapIndexed(func(i, v int) int { return i * v }, Count()), 3), []int{0, 1, 4})
+}
+
+func TestFilterIndexed(t *testing.T) {
+	evenPositions := func(i int, _ string) bool { return i%2 == 0 }
+	assertSequenceMatch(t, FilterIndexed(evenPositions, NewSeq("a", "b", "c", "d", "e")), []string{"a", "c", "e"})
+	assertSequenceMatch(t, Take(FilterIndexed(func(i, v int) bool { return i > 2 }, Count()), 2), []int{3, 4})
+}
+
+func TestFlatMap(t *testing.T) {
+	tokens := func(line string) iter.Seq[string] { return FromSlice(strings.Fields(line)) }
+	assertSequenceMatch(t, FlatMap(tokens, NewSeq("a b", "", "c")), []string{"a", "b", "c"})
+	assertSequenceMatch(t, Take(FlatMap(func(n int) iter.Seq[int] { return Repeat(n, n) }, Count()), 6), []int{1, 2, 2, 3, 3, 3})
+}
+
+func TestFlatten(t *testing.T) {
+	assertSequenceMatch(t, Flatten(NewSeq(NewSeq(1, 2), NewSeq[int](), NewSeq(3))), []int{1, 2, 3})
+	assertSequenceMatch(t,
+		Flatten(Values(GroupBy(NewSeq("a", "a", "b")))),
+		[]string{"a", "a", "b"},
+	)
+}
+
+func TestFlattenSlices(t *testing.T) {
+	assertSequenceMatch(t, FlattenSlices(Batched(NewSeq(1, 2, 3, 4, 5), 2)), []int{1, 2, 3, 4, 5})
+	assertSequenceMatch(t, FlattenSlices(NewSeq([]int{}, nil)), []int{})
+	assertSequenceMatch(t, Take(FlattenSlices(Batched(Count(), 3)), 4), []int{0, 1, 2, 3})
 }
 
 func TestCount(t *testing.T) {
 	assertSequenceMatch(t, Take(Count(), 3), []int{0, 1, 2})
 }
 
+func TestCountFrom(t *testing.T) {
+	assertSequenceMatch(t, Take(CountFrom(10, 5), 3), []int{10, 15, 20})
+	assertSequenceMatch(t, Take(CountFrom(3, -2), 4), []int{3, 1, -1, -3})
+	assertSequenceMatch(t, Take(CountFrom(7, 0), 2), []int{7, 7})
+}
+
 func TestCycle(t *testing.T) {
 	assertSequenceMatch(t, Take(Cycle(NewSeq(1, 2, 3)), 5), []int{1, 2, 3, 1, 2})
 }
@@ -76,6 +180,22 @@ func TestAccumulate(t *testing.T) {
 	assertSequenceMatch(t, runningProducts, []int{1, 2, 6})
 }
 
+func TestScanPairs(t *testing.T) {
+	type order struct {
+		qty   int
+		price float64
+	}
+	orders := func(yield func(string, order) bool) {
+		_ = yield("a", order{2, 1.5}) && yield("b", order{1, 4}) && yield("c", order{4, 0.25})
+	}
+
+	keys, totals := toSlice2(ScanPairs(orders, 0.0, func(acc float64, _ string, o order) float64 {
+		return acc + float64(o.qty)*o.price
+	}))
+	assert.Equal(t, []string{"a", "b", "c"}, keys)
+	assert.Equal(t, []float64{3, 7, 8}, totals)
+}
+
 func TestBatched(t *testing.T) {
 	assertSequenceMatch(t,
 		Batched(NewSeq(1, 2, 3, 4, 5, 6, 7, 8), 3),
@@ -83,6 +203,98 @@ func TestBatched(t *testing.T) {
 	)
 }
 
+func TestBatched2(t *testing.T) {
+	assertSequenceMatch(t,
+		Batched2(Enumerate(NewSeq("a", "b", "c", "d", "e")), 2),
+		[]map[int]string{{0: "a", 1: "b"}, {2: "c", 3: "d"}, {4: "e"}},
+	)
+
+	repeated := func(yield func(string, int) bool) {
+		_ = yield("x", 1) && yield("y", 2) && yield("x", 3) && yield("z", 4)
+	}
+	assertSequenceMatch(t, Batched2(repeated, 2), []map[string]int{{"x": 3, "y": 2}, {"z": 4}})
+}
+
+func TestBatchedSlice(t *testing.T) {
+	vals := []int{1, 2, 3, 4, 5}
+	assertSequenceMatch(t, BatchedSlice(vals, 2), [][]int{{1, 2}, {3, 4}, {5}})
+	assertSequenceMatch(t, BatchedSlice(vals, 5), [][]int{{1, 2, 3, 4, 5}})
+	assertSequenceMatch(t, BatchedSlice(vals, 0), [][]int{})
+	assertSequenceMatch(t, BatchedSlice([]int{}, 2), [][]int{})
+
+	for batch := range BatchedSlice(vals, 2) {
+		batch[0] *= 10
+		_ = append(batch, -1)
+	}
+	assert.Equal(t, []int{10, 2, 30, 4, 50}, vals)
+}
+
+func TestWindowedSlice(t *testing.T) {
+	vals := []int{1, 2, 3, 4, 5}
+	assertSequenceMatch(t, WindowedSlice(vals, 3, 1), [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}})
+	assertSequenceMatch(t, WindowedSlice(vals, 2, 2), [][]int{{1, 2}, {3, 4}})
+	assertSequenceMatch(t, WindowedSlice(vals, 1, 3), [][]int{{1}, {4}})
+	assertSequenceMatch(t, WindowedSlice(vals, 6, 1), [][]int{})
+	assertSequenceMatch(t, WindowedSlice(vals, 2, 0), [][]int{})
+
+	for w := range WindowedSlice(vals, 2, 1) {
+		assert.Equal(t, 2, cap(w))
+	}
+}
+
+func TestBatchedPairs(t *testing.T) {
+	assertSequenceMatch(t,
+		BatchedPairs(Enumerate(NewSeq("a", "b", "c")), 2),
+		[][]tuples.T2[int, string]{{tuples.Of2(0, "a"), tuples.Of2(1, "b")}, {tuples.Of2(2, "c")}},
+	)
+}
+
+func TestBatchedBySerializedSize(t *testing.T) {
+	size := func(s string) int { return len(s) }
+
+	assertSequenceMatch(t,
+		BatchedBySerializedSize(NewSeq("aa", "bbb", "c", "dddddd", "e", "f", "g"), size, 5, 2),
+		[][]string{{"aa", "bbb"}, {"c"}, {"dddddd"}, {"e", "f"}, {"g"}},
+	)
+
+	assertSequenceMatch(t,
+		BatchedBySerializedSize(NewSeq("a", "b", "c", "d"), size, 3, 0),
+		[][]string{{"a", "b", "c"}, {"d"}},
+	)
+}
+
+func TestChunkWhile(t *testing.T) {
+	assertSequenceMatch(t,
+		ChunkWhile(NewSeq(1, 2, 4, 9, 10, 11, 12, 15), func(a, b int) bool { return b == a+1 }),
+		[][]int{{1, 2}, {4}, {9, 10, 11, 12}, {15}},
+	)
+	assertSequenceMatch(t, ChunkWhile(NewSeq[int](), func(a, b int) bool { return true }), [][]int{})
+}
+
+func TestChunkWhileMaxGroupSize(t *testing.T) {
+	consecutive := func(a, b int) bool { return b == a+1 }
+	vals := NewSeq(1, 2, 3, 4, 5, 9, 10)
+
+	assertSequenceMatch(t,
+		ChunkWhile(vals, consecutive, WithMaxGroupSize(2)),
+		[][]int{{1, 2}, {3, 4}, {5}, {9, 10}},
+	)
+	assertSequenceMatch(t,
+		ChunkWhile(vals, consecutive, WithMaxGroupSize(2, DropOverflow)),
+		[][]int{{1, 2}, {9, 10}},
+	)
+
+	assertSequenceMatch(t, ChunkWhile(vals, consecutive, WithMaxGroupSize(2, ErrorOnOverflow)), [][]int{})
+
+	chunks, err := CollectErr(TryChunkWhile(vals, consecutive, WithMaxGroupSize(2, ErrorOnOverflow)))
+	assert.ErrorIs(t, err, ErrGroupOverflow)
+	assert.Empty(t, chunks)
+
+	chunks, err = CollectErr(TryChunkWhile(NewSeq(1, 2, 9, 10), consecutive, WithMaxGroupSize(2, ErrorOnOverflow)))
+	assert.NoError(t, err)
+	assert.Equal(t, [][]int{{1, 2}, {9, 10}}, chunks)
+}
+
 func TestCombinations(t *testing.T) {
 	assertSequenceMatch(t,
 		Combinations([]string{"A", "B", "C", "D"}, 2),
@@ -92,6 +304,63 @@ func TestCombinations(t *testing.T) {
 		Combinations([]int{0, 1, 2, 3}, 3),
 		[][]int{{0, 1, 2}, {0, 1, 3}, {0, 2, 3}, {1, 2, 3}},
 	)
+	assertSequenceMatch(t, Combinations([]int{0, 1}, 0), [][]int{{}})
+	assertSequenceMatch(t, Combinations([]int{0, 1}, 3), [][]int{})
+	assertSequenceMatch(t, Take(Combinations([]int{0, 1, 2}, 2), 1), [][]int{{0, 1}})
+}
+
+func TestNextCombination(t *testing.T) {
+	idx := []int{0, 1}
+	var got [][]int
+	for {
+		got = append(got, slices.Clone(idx))
+		if !NextCombination(idx, 4) {
+			break
+		}
+	}
+	assert.Equal(t, [][]int{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}}, got)
+	assert.Equal(t, []int{2, 3}, idx)
+}
+
+func TestCombinationsSharded(t *testing.T) {
+	vals := []string{"A", "B", "C", "D", "E"}
+
+	var all [][]string
+	for shard := range 3 {
+		all = append(all, toSlice(CombinationsSharded(vals, 2, shard, 3))...)
+	}
+	assert.Equal(t, toSlice(Combinations(vals, 2)), all)
+
+	assertSequenceMatch(t, CombinationsSharded(vals, 3, 1, 2), [][]string{
+		{"A", "D", "E"},
+		{"B", "C", "D"},
+		{"B", "C", "E"},
+		{"B", "D", "E"},
+		{"C", "D", "E"},
+	})
+	assertSequenceMatch(t, CombinationsSharded(vals, 5, 0, 2), [][]string{})
+	assertSequenceMatch(t, CombinationsSharded(vals, 5, 1, 2), [][]string{vals})
+
+	assertSequenceMatch(t, CombinationsSharded(vals, 6, 0, 2), [][]string{})
+	assertSequenceMatch(t, CombinationsSharded(vals, -1, 0, 2), [][]string{})
+	assert.Panics(t, func() { CombinationsSharded(vals, 2, 5, 4) })
+	assert.Panics(t, func() { CombinationsSharded(vals, 2, -1, 4) })
+	assert.Panics(t, func() { CombinationsSharded(vals, 2, 0, 0) })
+	assertSequenceMatch(t, CombinationsSharded(vals, 0, 0, 1), [][]string{{}})
+
+	// C(64, 32) overflows intermediate int arithmetic, but every shard must still start in order
+	large := slices.Collect(Take(Count(), 64))
+	var firsts [][]int
+	for shard := range 10 {
+		for c := range Take(CombinationsSharded(large, 32, shard, 10), 1) {
+			firsts = append(firsts, c)
+		}
+	}
+	assert.Len(t, firsts, 10)
+	assert.Equal(t, large[:32], firsts[0])
+	for i := 1; i < len(firsts); i++ {
+		assert.Negative(t, slices.Compare(firsts[i-1], firsts[i]))
+	}
 }
 
 func TestCombinationsWithReplacement(t *testing.T) {
@@ -99,6 +368,15 @@ func TestCombinationsWithReplacement(t *testing.T) {
 		CombinationsWithReplacement([]string{"A", "B", "C"}, 2),
 		[][]string{{"A", "A"}, {"A", "B"}, {"A", "C"}, {"B", "B"}, {"B", "C"}, {"C", "C"}},
 	)
+
+	assertSequenceMatch(t,
+		Take(CombinationsWithReplacement([]string{"A", "B", "C"}, 2), 2),
+		[][]string{{"A", "A"}, {"A", "B"}},
+	)
+	assertSequenceMatch(t,
+		Take(CombinationsWithReplacement([]string{"A", "B", "C"}, 2), 1),
+		[][]string{{"A", "A"}},
+	)
 }
 
 func TestCompress(t *testing.T) {
@@ -120,6 +398,19 @@ func TestDropWhile(t *testing.T) {
 	)
 }
 
+func TestDropWhile2(t *testing.T) {
+	keys, vals := toSlice2(DropWhile2(func(i int, s string) bool { return i < 2 || s == "x" }, Enumerate(NewSeq("a", "b", "x", "c", "x"))))
+	assert.Equal(t, []int{3, 4}, keys)
+	assert.Equal(t, []string{"c", "x"}, vals)
+}
+
+func TestFilter(t *testing.T) {
+	assertSequenceMatch(t,
+		Filter(func(x int) bool { return x < 5 }, NewSeq(1, 4, 6, 3, 8)),
+		[]int{1, 4, 3},
+	)
+}
+
 func TestFilterFalse(t *testing.T) {
 	assertSequenceMatch(t,
 		FilterFalse(func(x int) bool { return x < 5 }, NewSeq(1, 4, 6, 3, 8)),
@@ -135,9 +426,121 @@ func TestGroupBy(t *testing.T) {
 		"D": []string{"D"},
 	}
 
+	var keys []string
 	for k, g := range GroupBy(NewSeq("A", "A", "A", "A", "B", "B", "B", "C", "C", "D")) {
+		keys = append(keys, k)
 		assertSequenceMatch(t, g, want[k])
 	}
+	assert.Equal(t, []string{"A", "B", "C", "D"}, keys)
+
+	keys = nil
+	for k := range GroupBy(NewSeq("A", "A", "B", "A")) {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []string{"A", "B", "A"}, keys)
+}
+
+func TestGroupByFunc(t *testing.T) {
+	type person struct {
+		name string
+		team int
+	}
+	people := NewSeq(person{"ann", 1}, person{"bob", 1}, person{"cat", 2}, person{"dan", 1})
+	team := func(p person) int { return p.team }
+
+	var teams []int
+	var names [][]string
+	for k, g := range GroupByFunc(people, team) {
+		teams = append(teams, k)
+		names = append(names, toSlice(Map(func(p person) string { return p.name }, g)))
+	}
+	assert.Equal(t, []int{1, 2, 1}, teams)
+	assert.Equal(t, [][]string{{"ann", "bob"}, {"cat"}, {"dan"}}, names)
+
+	// partially consumed and stale groups
+	var groups []iter.Seq[int]
+	var firsts []int
+	for _, g := range GroupByFunc(NewSeq(1, 3, 5, 2, 4, 7), func(x int) int { return x % 2 }) {
+		groups = append(groups, g)
+		for v := range g {
+			firsts = append(firsts, v)
+			break
+		}
+	}
+	assert.Equal(t, []int{1, 2, 7}, firsts)
+	assertSequenceMatch(t, groups[0], []int{})
+}
+
+func TestGroupByMaxGroupSize(t *testing.T) {
+	vals := NewSeq("A", "A", "A", "A", "A", "B", "C", "C")
+
+	var keys []string
+	var groups [][]string
+	for k, g := range GroupBy(vals, WithMaxGroupSize(2)) {
+		keys = append(keys, k)
+		groups = append(groups, toSlice(g))
+	}
+	assert.Equal(t, []string{"A", "A", "A", "B", "C"}, keys)
+	assert.Equal(t, [][]string{{"A", "A"}, {"A", "A"}, {"A"}, {"B"}, {"C", "C"}}, groups)
+
+	// groups left unread still spill at the cap
+	keys = nil
+	for k := range GroupBy(vals, WithMaxGroupSize(2)) {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []string{"A", "A", "A", "B", "C"}, keys)
+
+	keys, groups = nil, nil
+	for k, g := range GroupBy(vals, WithMaxGroupSize(2, DropOverflow)) {
+		keys = append(keys, k)
+		groups = append(groups, toSlice(g))
+	}
+	assert.Equal(t, []string{"A", "B", "C"}, keys)
+	assert.Equal(t, [][]string{{"A", "A"}, {"B"}, {"C", "C"}}, groups)
+
+	keys, groups = nil, nil
+	for k, g := range GroupBy(vals, WithMaxGroupSize(3, ErrorOnOverflow)) {
+		keys = append(keys, k)
+		groups = append(groups, toSlice(g))
+	}
+	assert.Equal(t, []string{"A"}, keys)
+	assert.Equal(t, [][]string{{"A", "A", "A"}}, groups)
+
+	keys, groups = nil, nil
+	var errs []error
+	for k, g := range TryGroupBy(vals, WithMaxGroupSize(3, ErrorOnOverflow)) {
+		keys = append(keys, k)
+		group, err := CollectErr(g)
+		groups = append(groups, group)
+		errs = append(errs, err)
+	}
+	assert.Equal(t, []string{"A"}, keys)
+	assert.Equal(t, [][]string{{"A", "A", "A"}}, groups)
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], ErrGroupOverflow)
+	}
+
+	// an overflowing group left unread reports the error in an extra group
+	keys, errs = nil, nil
+	for k, g := range TryGroupBy(vals, WithMaxGroupSize(3, ErrorOnOverflow)) {
+		keys = append(keys, k)
+		if len(keys) > 1 {
+			_, err := CollectErr(g)
+			errs = append(errs, err)
+		}
+	}
+	assert.Equal(t, []string{"A", "A"}, keys)
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], ErrGroupOverflow)
+	}
+
+	keys = nil
+	for k, g := range TryGroupBy(vals, WithMaxGroupSize(5, ErrorOnOverflow)) {
+		keys = append(keys, k)
+		_, err := CollectErr(g)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []string{"A", "B", "C"}, keys)
 }
 
 func TestSlice(t *testing.T) {
@@ -193,6 +596,53 @@ func TestPermutations(t *testing.T) {
 			{2, 1, 0},
 		},
 	)
+
+	assertSequenceMatch(t, Permutations([]int{0, 1}, 0), [][]int{{}})
+	assertSequenceMatch(t, Permutations([]int{0, 1}, 3), [][]int{})
+	assertSequenceMatch(t, Take(Permutations([]int{0, 1, 2}, 2), 2), [][]int{{0, 1}, {0, 2}})
+}
+
+func TestNextPermutation(t *testing.T) {
+	p := []int{0, 1, 2}
+	var got [][]int
+	for {
+		got = append(got, slices.Clone(p))
+		if !NextPermutation(p) {
+			break
+		}
+	}
+	assert.Equal(t, [][]int{{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0}}, got)
+	assert.Equal(t, []int{2, 1, 0}, p)
+
+	dupes := []int{1, 1, 2}
+	assert.True(t, NextPermutation(dupes))
+	assert.Equal(t, []int{1, 2, 1}, dupes)
+	assert.True(t, NextPermutation(dupes))
+	assert.Equal(t, []int{2, 1, 1}, dupes)
+	assert.False(t, NextPermutation(dupes))
+}
+
+func TestPermutationsPruned(t *testing.T) {
+	always := func([]int) bool { return true }
+	assertSequenceMatch(t, PermutationsPruned([]int{0, 1, 2}, always), toSlice(Permutations([]int{0, 1, 2}, 3)))
+
+	// n-queens: no two queens share a diagonal
+	noDiagonal := func(prefix []int) bool {
+		last := len(prefix) - 1
+		for i := range last {
+			if last-i == prefix[last]-prefix[i] || last-i == prefix[i]-prefix[last] {
+				return false
+			}
+		}
+		return true
+	}
+	assertSequenceMatch(t, PermutationsPruned([]int{0, 1, 2, 3}, noDiagonal), [][]int{{1, 3, 0, 2}, {2, 0, 3, 1}})
+
+	var n int
+	for range PermutationsPruned([]int{0, 1, 2, 3, 4, 5, 6, 7}, noDiagonal) {
+		n++
+	}
+	assert.Equal(t, 92, n)
 }
 
 func TestProduct(t *testing.T) {
@@ -209,6 +659,9 @@ func TestProduct(t *testing.T) {
 			{'D', 'y'},
 		},
 	)
+
+	assertSequenceMatch(t, Product([]int{1, 2}, []int{}), [][]int{})
+	assertSequenceMatch(t, Product[int](), [][]int{{}})
 }
 
 func TestProductRepeat(t *testing.T) {
@@ -227,6 +680,49 @@ func TestProductRepeat(t *testing.T) {
 	)
 }
 
+func TestProductPruned(t *testing.T) {
+	pool := [][]int{{1, 2, 3}, {1, 2, 3}, {1, 2, 3}}
+
+	var calls int
+	increasing := func(prefix []int) bool {
+		calls++
+		n := len(prefix)
+		return n > 1 && prefix[n-1] <= prefix[n-2]
+	}
+
+	assertSequenceMatch(t, ProductPruned(pool, increasing), [][]int{{1, 2, 3}})
+	assert.Less(t, calls, 27)
+
+	never := func([]int) bool { return false }
+	assertSequenceMatch(t,
+		ProductPruned([][]byte{[]byte("AB"), []byte("xy")}, func([]byte) bool { return false }),
+		toSlice(Product([]byte("AB"), []byte("xy"))),
+	)
+	assertSequenceMatch(t, ProductPruned([][]int{{1}, {}}, never), [][]int{})
+	assertSequenceMatch(t, ProductPruned([][]int{}, never), [][]int{{}})
+}
+
+func TestProduct2(t *testing.T) {
+	var got []string
+	for name, n := range Product2([]string{"a", "b"}, []int{1, 2, 3}) {
+		got = append(got, name+strconv.Itoa(n))
+	}
+	assert.Equal(t, []string{"a1", "a2", "a3", "b1", "b2", "b3"}, got)
+}
+
+func TestProduct3(t *testing.T) {
+	assertSequenceMatch(t,
+		Product3([]string{"x", "y"}, []int{1, 2}, []bool{true}),
+		[]tuples.T3[string, int, bool]{
+			tuples.Of3("x", 1, true),
+			tuples.Of3("x", 2, true),
+			tuples.Of3("y", 1, true),
+			tuples.Of3("y", 2, true),
+		},
+	)
+	assertSequenceMatch(t, Product3([]string{"x"}, []int{}, []bool{true}), []tuples.T3[string, int, bool]{})
+}
+
 func TestMap(t *testing.T) {
 	assertSequenceMatch(t,
 		Map(func(x int) byte { return byte('0' + x) }, NewSeq(0, 1, 2)),
@@ -234,6 +730,23 @@ func TestMap(t *testing.T) {
 	)
 }
 
+func TestStarMap(t *testing.T) {
+	assertSequenceMatch(t,
+		StarMap(func(i int, s string) string { return strconv.Itoa(i) + s }, Enumerate(NewSeq("a", "b"))),
+		[]string{"0a", "1b"},
+	)
+	assertSequenceMatch(t,
+		StarMap(func(a, b int) int { return b - a }, Pairwise(NewSeq(1, 4, 9, 16))),
+		[]int{3, 5, 7},
+	)
+}
+
+func TestMap2(t *testing.T) {
+	keys, vals := toSlice2(Map2(func(i int, s string) (string, int) { return s, i * 10 }, Enumerate(NewSeq("a", "b"))))
+	assert.Equal(t, []string{"a", "b"}, keys)
+	assert.Equal(t, []int{0, 10}, vals)
+}
+
 func TestTakeWhile(t *testing.T) {
 	assertSequenceMatch(t,
 		TakeWhile(func(x int) bool { return x < 5 }, NewSeq(1, 4, 6, 3, 8)),
@@ -241,6 +754,30 @@ func TestTakeWhile(t *testing.T) {
 	)
 }
 
+func TestTakeWhile2(t *testing.T) {
+	keys, vals := toSlice2(TakeWhile2(func(i int, s string) bool { return i < 3 && s != "x" }, Enumerate(NewSeq("a", "b", "c", "d"))))
+	assert.Equal(t, []int{0, 1, 2}, keys)
+	assert.Equal(t, []string{"a", "b", "c"}, vals)
+
+	keys, _ = toSlice2(TakeWhile2(func(i int, s string) bool { return i < 3 && s != "x" }, Enumerate(NewSeq("a", "x", "c"))))
+	assert.Equal(t, []int{0}, keys)
+}
+
+func TestDefaultIfEmpty(t *testing.T) {
+	assertSequenceMatch(t, DefaultIfEmpty(NewSeq(1, 2), 9), []int{1, 2})
+	assertSequenceMatch(t, DefaultIfEmpty(NewSeq[int](), 9, 8), []int{9, 8})
+	assertSequenceMatch(t, DefaultIfEmpty(NewSeq[int]()), []int{})
+}
+
+func TestOnEmpty(t *testing.T) {
+	var calls int
+	assertSequenceMatch(t, OnEmpty(NewSeq(1, 2), func() { calls++ }), []int{1, 2})
+	assert.Equal(t, 0, calls)
+
+	assertSequenceMatch(t, OnEmpty(NewSeq[int](), func() { calls++ }), []int{})
+	assert.Equal(t, 1, calls)
+}
+
 func TestTee(t *testing.T) {
 	vals := []int{2, 4, 6, 8}
 	seq0, seq1 := Tee(FromSlice(vals))
@@ -264,6 +801,60 @@ func TestTee(t *testing.T) {
 	}
 }
 
+func TestTeeSingleUse(t *testing.T) {
+	src, stop := iter.Pull(Count())
+	defer stop()
+	once := func(yield func(int) bool) {
+		for {
+			v, ok := src()
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+
+	a, b := Tee(Take(once, 5))
+	next, stopA := iter.Pull(a)
+	defer stopA()
+
+	first, _ := next()
+	assert.Equal(t, 0, first)
+	assertSequenceMatch(t, b, []int{0, 1, 2, 3, 4})
+
+	rest := []int{}
+	for {
+		v, ok := next()
+		if !ok {
+			break
+		}
+		rest = append(rest, v)
+	}
+	assert.Equal(t, []int{1, 2, 3, 4}, rest)
+}
+
+func TestTeeN(t *testing.T) {
+	var pulled int
+	src := Map(func(x int) int { pulled++; return x }, NewSeq(1, 2, 3))
+
+	seqs := TeeN(src, 3)
+	assert.Len(t, seqs, 3)
+	assertSequenceMatch(t, Take(seqs[0], 1), []int{1})
+	assertSequenceMatch(t, seqs[1], []int{1, 2, 3})
+	assertSequenceMatch(t, seqs[2], []int{1, 2, 3})
+	assert.Equal(t, 3, pulled)
+
+	// a finished branch cannot be ranged over again
+	assertSequenceMatch(t, seqs[1], []int{})
+
+	var stopped bool
+	infinite := OnClose(Count(), func() { stopped = true })
+	branches := TeeN(infinite, 2)
+	assertSequenceMatch(t, Take(branches[0], 2), []int{0, 1})
+	assert.False(t, stopped)
+	assertSequenceMatch(t, Take(branches[1], 3), []int{0, 1, 2})
+	assert.True(t, stopped)
+}
+
 func TestZip(t *testing.T) {
 	chrs := FromSlice([]byte("2468"))
 	nums := FromSlice([]int{2, 4, 6, 8})
@@ -273,6 +864,90 @@ func TestZip(t *testing.T) {
 	}
 }
 
+func TestZipPairs(t *testing.T) {
+	assertSequenceMatch(t,
+		ZipPairs(NewSeq("a", "b", "c"), Count()),
+		[]tuples.T2[string, int]{tuples.Of2("a", 0), tuples.Of2("b", 1), tuples.Of2("c", 2)},
+	)
+}
+
+func TestZip3(t *testing.T) {
+	assertSequenceMatch(t,
+		Zip3(NewSeq("a", "b", "c"), Count(), NewSeq(true, false)),
+		[]tuples.T3[string, int, bool]{tuples.Of3("a", 0, true), tuples.Of3("b", 1, false)},
+	)
+}
+
+func TestZipWith(t *testing.T) {
+	repeat := func(s string, n int) string { return strings.Repeat(s, n) }
+	assertSequenceMatch(t, ZipWith(repeat, NewSeq("a", "b", "c"), NewSeq(1, 3)), []string{"a", "bbb"})
+	assertSequenceMatch(t, Take(ZipWith(repeat, Repeat("x", -1), Count()), 3), []string{"", "x", "xx"})
+}
+
+func TestUnzip(t *testing.T) {
+	keys, vals := Unzip(Zip(NewSeq(1, 2, 3), NewSeq("a", "b", "c")))
+	assertSequenceMatch(t, vals, []string{"a", "b", "c"})
+	assertSequenceMatch(t, keys, []int{1, 2, 3})
+
+	var pulled int
+	counted := func(yield func(int, int) bool) {
+		for i := range 10 {
+			pulled++
+			if !yield(i, i*i) {
+				return
+			}
+		}
+	}
+	keys2, vals2 := Unzip(counted)
+	assertSequenceMatch(t, Take(keys2, 2), []int{0, 1})
+	assertSequenceMatch(t, Take(vals2, 3), []int{0, 1, 4})
+	assert.Equal(t, 3, pulled)
+}
+
+func TestZipLongest(t *testing.T) {
+	keys, vals := toSlice2(ZipLongest(NewSeq("a", "b", "c"), NewSeq(1), "-", 0))
+	assert.Equal(t, []string{"a", "b", "c"}, keys)
+	assert.Equal(t, []int{1, 0, 0}, vals)
+
+	keys, vals = toSlice2(ZipLongest(NewSeq("a"), NewSeq(1, 2), "-", 0))
+	assert.Equal(t, []string{"a", "-"}, keys)
+	assert.Equal(t, []int{1, 2}, vals)
+}
+
+func TestZipLongestN(t *testing.T) {
+	assertSequenceMatch(t,
+		ZipLongestN(-1, NewSeq(1, 2, 3), NewSeq(4), NewSeq(5, 6)),
+		[][]int{{1, 4, 5}, {2, -1, 6}, {3, -1, -1}},
+	)
+	assertSequenceMatch(t, ZipLongestN(0), [][]int{})
+}
+
+func TestZipByIndex(t *testing.T) {
+	evens := func(yield func(int, string) bool) {
+		for _, i := range []int{0, 2, 4, 6} {
+			if !yield(i, string(rune('a'+i))) {
+				return
+			}
+		}
+	}
+	triples := func(yield func(int, int) bool) {
+		for _, i := range []int{0, 3, 6, 9} {
+			if !yield(i, i*10) {
+				return
+			}
+		}
+	}
+
+	var keys []int
+	var pairs []tuples.T2[string, int]
+	for i, p := range ZipByIndex(evens, triples) {
+		keys = append(keys, i)
+		pairs = append(pairs, p)
+	}
+	assert.Equal(t, []int{0, 6}, keys)
+	assert.Equal(t, []tuples.T2[string, int]{tuples.Of2("a", 0), tuples.Of2("g", 60)}, pairs)
+}
+
 func TestPullZip3(t *testing.T) {
 	chrs := FromSlice([]byte("2468"))
 	nums := FromSlice([]int{2, 4, 6, 8})
@@ -0,0 +1,47 @@
+// Package itmath provides numeric operations over sequences
+package itmath
+
+import (
+	"iter"
+
+	it "github.com/astonm/go-itertools"
+)
+
+// Number is satisfied by the built-in integer and floating-point types. It is the same constraint as [itertools.Number]
+type Number = it.Number
+
+// Abs yields the absolute value of each value of s
+func Abs[T Number](s iter.Seq[T]) iter.Seq[T] {
+	return it.Map(func(v T) T {
+		if v < 0 {
+			return -v
+		}
+		return v
+	}, s)
+}
+
+// Clamp yields each value of s limited to the range [lo, hi]
+func Clamp[T Number](s iter.Seq[T], lo, hi T) iter.Seq[T] {
+	return it.Map(func(v T) T { return min(max(v, lo), hi) }, s)
+}
+
+// Scale yields each value of s multiplied by factor
+func Scale[T Number](s iter.Seq[T], factor T) iter.Seq[T] {
+	return it.Map(func(v T) T { return v * factor }, s)
+}
+
+// Diff yields the difference between each value of s and the one before it, so it yields one value fewer than s
+func Diff[T Number](s iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for prev, v := range it.Pairwise(s) {
+			if !yield(v - prev) {
+				return
+			}
+		}
+	}
+}
+
+// CumSum yields the running total of s
+func CumSum[T Number](s iter.Seq[T]) iter.Seq[T] {
+	return it.Accumulate(s, func(a, b T) T { return a + b }, 0)
+}
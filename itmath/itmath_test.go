@@ -0,0 +1,34 @@
+package itmath
+
+import (
+	"slices"
+	"testing"
+
+	it "github.com/astonm/go-itertools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAbs(t *testing.T) {
+	assert.Equal(t, []int{3, 0, 2}, slices.Collect(Abs(it.NewSeq(-3, 0, 2))))
+	assert.Equal(t, []float64{1.5}, slices.Collect(Abs(it.NewSeq(-1.5))))
+	assert.Equal(t, []uint{7}, slices.Collect(Abs(it.NewSeq[uint](7))))
+}
+
+func TestClamp(t *testing.T) {
+	assert.Equal(t, []int{0, 3, 10, 10}, slices.Collect(Clamp(it.NewSeq(-5, 3, 10, 42), 0, 10)))
+}
+
+func TestScale(t *testing.T) {
+	assert.Equal(t, []float64{0.5, -1, 0}, slices.Collect(Scale(it.NewSeq(1.0, -2.0, 0.0), 0.5)))
+}
+
+func TestDiff(t *testing.T) {
+	assert.Equal(t, []int{2, -1, 5}, slices.Collect(Diff(it.NewSeq(1, 3, 2, 7))))
+	assert.Empty(t, slices.Collect(Diff(it.NewSeq(1))))
+	assert.Equal(t, []int{1, 1}, slices.Collect(it.Take(Diff(it.Count()), 2)))
+}
+
+func TestCumSum(t *testing.T) {
+	assert.Equal(t, []int{1, 3, 6, 10}, slices.Collect(CumSum(it.NewSeq(1, 2, 3, 4))))
+	assert.Equal(t, []float64{0.5, 0.75}, slices.Collect(CumSum(it.NewSeq(0.5, 0.25))))
+}
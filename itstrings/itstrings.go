@@ -0,0 +1,35 @@
+// Package itstrings provides the common per-line transforms of text pipelines as sequence functions
+package itstrings
+
+import (
+	"iter"
+	"regexp"
+	"strings"
+
+	it "github.com/astonm/go-itertools"
+)
+
+// TrimSpaceEach yields each value of s with leading and trailing white space removed
+func TrimSpaceEach(s iter.Seq[string]) iter.Seq[string] {
+	return it.Map(strings.TrimSpace, s)
+}
+
+// ToLowerEach yields each value of s converted to lower case
+func ToLowerEach(s iter.Seq[string]) iter.Seq[string] {
+	return it.Map(strings.ToLower, s)
+}
+
+// MatchEach yields only the values of s that re matches
+func MatchEach(s iter.Seq[string], re *regexp.Regexp) iter.Seq[string] {
+	return it.Filter(re.MatchString, s)
+}
+
+// SplitEach yields each value of s split into the substrings separated by sep, as with [strings.Split]
+func SplitEach(s iter.Seq[string], sep string) iter.Seq[[]string] {
+	return it.Map(func(v string) []string { return strings.Split(v, sep) }, s)
+}
+
+// PrefixedWith yields only the values of s that begin with prefix
+func PrefixedWith(s iter.Seq[string], prefix string) iter.Seq[string] {
+	return it.Filter(func(v string) bool { return strings.HasPrefix(v, prefix) }, s)
+}
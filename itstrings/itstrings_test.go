@@ -0,0 +1,29 @@
+package itstrings
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+
+	it "github.com/astonm/go-itertools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransforms(t *testing.T) {
+	lines := it.NewSeq("  INFO start ", "WARN disk\t", "info done")
+
+	assert.Equal(t, []string{"INFO start", "WARN disk", "info done"}, slices.Collect(TrimSpaceEach(lines)))
+	assert.Equal(t, []string{"  info start ", "warn disk\t", "info done"}, slices.Collect(ToLowerEach(lines)))
+	assert.Equal(t,
+		[][]string{{"INFO", "start"}, {"WARN", "disk"}, {"info", "done"}},
+		slices.Collect(SplitEach(TrimSpaceEach(lines), " ")),
+	)
+}
+
+func TestFilters(t *testing.T) {
+	lines := it.NewSeq("GET /a 200", "POST /b 500", "GET /c 404")
+
+	assert.Equal(t, []string{"POST /b 500", "GET /c 404"}, slices.Collect(MatchEach(lines, regexp.MustCompile(` [45]\d\d$`))))
+	assert.Equal(t, []string{"GET /a 200", "GET /c 404"}, slices.Collect(PrefixedWith(lines, "GET ")))
+	assert.Empty(t, slices.Collect(PrefixedWith(lines, "PUT ")))
+}
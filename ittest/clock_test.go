@@ -0,0 +1,24 @@
+package ittest
+
+import (
+	"testing"
+	"time"
+
+	it "github.com/astonm/go-itertools"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ it.Clock = (*FakeClock)(nil)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+
+	timer := c.After(time.Minute)
+	c.Sleep(30 * time.Second)
+	assert.Empty(t, timer)
+
+	c.Advance(30 * time.Second)
+	assert.Equal(t, start.Add(time.Minute), <-timer)
+	assert.Equal(t, start.Add(time.Minute), c.Now())
+}
@@ -0,0 +1,99 @@
+package ittest
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+	"strings"
+)
+
+// diffContext is the number of elements DiffSeqs shows on each side of the first divergence
+const diffContext = 3
+
+// DiffSeqs compares want and got element by element with [reflect.DeepEqual], returning an empty string if they match. Otherwise it describes the index of the first divergence along with the surrounding elements of both sequences, marking the differing element and the end of a sequence that ran out early
+func DiffSeqs[T any](want, got iter.Seq[T]) string {
+	nextWant, stopWant := iter.Pull(want)
+	defer stopWant()
+	nextGot, stopGot := iter.Pull(got)
+	defer stopGot()
+
+	var before []pair[T]
+	for i := 0; ; i++ {
+		w, okW := nextWant()
+		g, okG := nextGot()
+		if !okW && !okG {
+			return ""
+		}
+
+		at := pair[T]{w, g, okW, okG}
+		if okW && okG && reflect.DeepEqual(w, g) {
+			before = append(before, at)
+			if len(before) > diffContext {
+				before = before[1:]
+			}
+			continue
+		}
+
+		after := make([]pair[T], 0, diffContext)
+		for range diffContext {
+			w, okW := nextWant()
+			g, okG := nextGot()
+			if !okW && !okG {
+				break
+			}
+			after = append(after, pair[T]{w, g, okW, okG})
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "sequences differ at index %d\n", i)
+		fmt.Fprintf(&b, "  want: %s\n", renderWindow(i-len(before), before, at, after, true))
+		fmt.Fprintf(&b, "   got: %s", renderWindow(i-len(before), before, at, after, false))
+		return b.String()
+	}
+}
+
+// pair holds the elements of both sequences at one index, and whether each had one
+type pair[T any] struct {
+	want, got     T
+	okWant, okGot bool
+}
+
+// renderWindow formats one side of the elements around a divergence
+func renderWindow[T any](start int, before []pair[T], at pair[T], after []pair[T], wantSide bool) string {
+	var parts []string
+	if start > 0 {
+		parts = append(parts, "...")
+	}
+
+	render := func(p pair[T], mark bool) bool {
+		v, ok := p.got, p.okGot
+		if wantSide {
+			v, ok = p.want, p.okWant
+		}
+
+		s := "<end>"
+		if ok {
+			s = fmt.Sprintf("%v", v)
+		}
+		if mark {
+			s = ">" + s + "<"
+		}
+		parts = append(parts, s)
+		return ok
+	}
+
+	for _, p := range before {
+		render(p, false)
+	}
+	more := render(at, true)
+	for _, p := range after {
+		if !more {
+			break
+		}
+		more = render(p, false)
+	}
+	if more && len(after) == diffContext {
+		parts = append(parts, "...")
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
@@ -0,0 +1,43 @@
+package ittest
+
+import (
+	"testing"
+
+	it "github.com/astonm/go-itertools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSeqs(t *testing.T) {
+	assert.Empty(t, DiffSeqs(it.NewSeq(1, 2, 3), it.NewSeq(1, 2, 3)))
+	assert.Empty(t, DiffSeqs(it.NewSeq[int](), it.NewSeq[int]()))
+	assert.Empty(t, DiffSeqs(it.NewSeq([]int{1}, nil), it.NewSeq([]int{1}, nil)))
+
+	assert.Equal(t, ""+
+		"sequences differ at index 5\n"+
+		"  want: [... 2 3 4 >5< 6 7 8 ...]\n"+
+		"   got: [... 2 3 4 >50< 6 7 8 ...]",
+		DiffSeqs(
+			it.Take(it.Count(), 20),
+			it.Map(func(x int) int {
+				if x == 5 {
+					return 50
+				}
+				return x
+			}, it.Count()),
+		),
+	)
+
+	assert.Equal(t, ""+
+		"sequences differ at index 2\n"+
+		"  want: [a b >c< d]\n"+
+		"   got: [a b ><end><]",
+		DiffSeqs(it.NewSeq("a", "b", "c", "d"), it.NewSeq("a", "b")),
+	)
+
+	assert.Equal(t, ""+
+		"sequences differ at index 0\n"+
+		"  want: [><end><]\n"+
+		"   got: [>x< y]",
+		DiffSeqs(it.NewSeq[string](), it.NewSeq("x", "y")),
+	)
+}
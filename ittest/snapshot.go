@@ -0,0 +1,64 @@
+// Package ittest provides helpers for testing code built on Go iterators
+package ittest
+
+import (
+	"errors"
+	"flag"
+	"io/fs"
+	"iter"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("ittest.update", false, "rewrite golden files compared by SnapshotSeq")
+
+// SnapshotSeq renders each value of s with format, one per line, and compares the result to the golden file testdata/<name>.golden. Running the test with -ittest.update writes the golden file instead
+func SnapshotSeq[T any](t testing.TB, name string, s iter.Seq[T], format func(T) string) {
+	t.Helper()
+
+	var b strings.Builder
+	for v := range s {
+		b.WriteString(format(v))
+		b.WriteByte('\n')
+	}
+	got := b.String()
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("golden file %s does not exist, run with -ittest.update to create it", path)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != string(want) {
+		wantLines := strings.Split(string(want), "\n")
+		gotLines := strings.Split(got, "\n")
+		for i := range max(len(wantLines), len(gotLines)) {
+			var w, g string
+			if i < len(wantLines) {
+				w = wantLines[i]
+			}
+			if i < len(gotLines) {
+				g = gotLines[i]
+			}
+			if w != g {
+				t.Errorf("sequence does not match %s at line %d:\n  want: %q\n   got: %q", path, i+1, w, g)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package ittest
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	it "github.com/astonm/go-itertools"
+)
+
+type recordingTB struct {
+	testing.TB
+	failed bool
+	msg    string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.failed = true
+	r.msg = fmt.Sprintf(format, args...)
+}
+
+func (r *recordingTB) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+}
+
+func (r *recordingTB) Fatal(args ...any) {
+	r.failed = true
+	r.msg = fmt.Sprint(args...)
+}
+
+func TestSnapshotSeq(t *testing.T) {
+	format := func(v []string) string { return fmt.Sprint(v) }
+
+	SnapshotSeq(t, "combinations", it.Combinations([]string{"A", "B", "C", "D"}, 2), format)
+
+	rec := &recordingTB{TB: t}
+	SnapshotSeq(rec, "combinations", it.Combinations([]string{"A", "B", "C", "E"}, 2), format)
+	if !rec.failed {
+		t.Fatal("expected mismatched sequence to fail")
+	}
+	if want := "sequence does not match testdata/combinations.golden at line 3:\n  want: \"[A D]\"\n   got: \"[A E]\""; rec.msg != want {
+		t.Errorf("unexpected failure message %q", rec.msg)
+	}
+
+	rec = &recordingTB{TB: t}
+	SnapshotSeq(rec, "missing", it.NewSeq(1), strconv.Itoa)
+	if !rec.failed {
+		t.Fatal("expected missing golden file to fail")
+	}
+}
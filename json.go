@@ -0,0 +1,91 @@
+package itertools
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+)
+
+// DecodeJSON yields the values read by dec, which may hold either a single JSON array, decoded as by [DecodeJSONArray], or a stream of whitespace-separated values such as NDJSON, decoded as by [DecodeJSONStream]. Input starting with '[' is always taken to be an array, so a stream of arrays must be read with DecodeJSONStream
+func DecodeJSON[T any](dec *json.Decoder) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		// More buffers the start of the first value, so its opening byte can be checked without consuming it
+		if dec.More() {
+			if c, err := firstNonSpace(dec.Buffered()); err == nil && c == '[' {
+				DecodeJSONArray[T](dec)(yield)
+				return
+			}
+		}
+		DecodeJSONStream[T](dec)(yield)
+	}
+}
+
+// DecodeJSONArray yields the elements of the single JSON array read by dec one at a time. Elements that do not fit T are reported as [*json.UnmarshalTypeError] and decoding continues, while malformed input, input that is not an array, or anything but whitespace after the closing bracket ends the sequence with a terminal error, [ErrTrailingJSON] in the last case
+func DecodeJSONArray[T any](dec *json.Decoder) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		if err == nil && tok != json.Delim('[') {
+			err = fmt.Errorf("itertools: expected JSON array, found %v", tok)
+		}
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		for dec.More() {
+			var v T
+			err := dec.Decode(&v)
+			if !yield(v, err) || !skippableJSON(err) {
+				return
+			}
+		}
+
+		if _, err := dec.Token(); err != nil {
+			yield(zero, err)
+			return
+		}
+		if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+			yield(zero, ErrTrailingJSON)
+		}
+	}
+}
+
+// DecodeJSONStream yields the values read by dec from a stream of whitespace-separated JSON values such as NDJSON, each decoded whole into T. Values that do not fit T are reported as [*json.UnmarshalTypeError] and decoding continues, while malformed input ends the sequence with a terminal error
+func DecodeJSONStream[T any](dec *json.Decoder) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			var v T
+			err := dec.Decode(&v)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if !yield(v, err) || !skippableJSON(err) {
+				return
+			}
+		}
+	}
+}
+
+// skippableJSON reports whether decoding can carry on past err, which is the case when the value was well-formed but did not fit its target
+func skippableJSON(err error) bool {
+	var typeErr *json.UnmarshalTypeError
+	return err == nil || errors.As(err, &typeErr)
+}
+
+func firstNonSpace(r io.Reader) (byte, error) {
+	br := bufio.NewReader(r)
+	for {
+		c, err := br.ReadByte()
+		if err != nil || !strings.ContainsRune(" \t\r\n", rune(c)) {
+			return c, err
+		}
+	}
+}
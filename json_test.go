@@ -0,0 +1,127 @@
+package itertools
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type jsonEvent struct {
+	ID   int    `json:"id"`
+	Kind string `json:"kind"`
+}
+
+func TestDecodeJSON(t *testing.T) {
+	decode := func(input string) ([]jsonEvent, []error) {
+		return CollectErrs(DecodeJSON[jsonEvent](json.NewDecoder(strings.NewReader(input))), 0)
+	}
+
+	want := []jsonEvent{{1, "a"}, {2, "b"}}
+
+	got, errs := decode(`  [{"id": 1, "kind": "a"}, {"id": 2, "kind": "b"}]`)
+	assert.Empty(t, errs)
+	assert.Equal(t, want, got)
+
+	got, errs = decode("{\"id\": 1, \"kind\": \"a\"}\n{\"id\": 2, \"kind\": \"b\"}\n")
+	assert.Empty(t, errs)
+	assert.Equal(t, want, got)
+
+	got, errs = decode("")
+	assert.Empty(t, errs)
+	assert.Empty(t, got)
+
+	got, errs = decode(`[]`)
+	assert.Empty(t, errs)
+	assert.Empty(t, got)
+
+	got, errs = decode(`[{"id": 1, "kind": "a"}, {"id": "x"}, {"id": 2, "kind": "b"}]`)
+	assert.Equal(t, want, got)
+	assert.Len(t, errs, 1)
+
+	// a leading '[' always means an array, so a stream of arrays reads as one array with trailing data
+	ints, errs := CollectErrs(DecodeJSON[int](json.NewDecoder(strings.NewReader("[1,2]\n[3,4]\n"))), 0)
+	assert.Equal(t, []int{1, 2}, ints)
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], ErrTrailingJSON)
+	}
+}
+
+func TestDecodeJSONArray(t *testing.T) {
+	decode := func(input string) ([]jsonEvent, []error) {
+		return CollectErrs(DecodeJSONArray[jsonEvent](json.NewDecoder(strings.NewReader(input))), 0)
+	}
+
+	want := []jsonEvent{{1, "a"}, {2, "b"}}
+
+	got, errs := decode(`  [{"id": 1, "kind": "a"}, {"id": 2, "kind": "b"}]` + "\n")
+	assert.Empty(t, errs)
+	assert.Equal(t, want, got)
+
+	got, errs = decode(`[]`)
+	assert.Empty(t, errs)
+	assert.Empty(t, got)
+
+	got, errs = decode(`[{"id": 1, "kind": "a"}, {"id": "x"}, {"id": 2, "kind": "b"}]`)
+	assert.Equal(t, want, got)
+	assert.Len(t, errs, 1)
+
+	got, errs = decode(`[{"id": 1, "kind": "a"}, {"id": 2,`)
+	assert.Equal(t, []jsonEvent{{1, "a"}}, got)
+	assert.Len(t, errs, 1)
+
+	got, errs = decode(`[{"id": 1, "kind": "a"}] [{"id": 2}]`)
+	assert.Equal(t, []jsonEvent{{1, "a"}}, got)
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], ErrTrailingJSON)
+	}
+
+	_, errs = decode(`[{"id": 1}] garbage`)
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], ErrTrailingJSON)
+	}
+
+	_, errs = decode(`{"id": 1, "kind": "a"}`)
+	assert.Len(t, errs, 1)
+
+	_, errs = decode("")
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], io.ErrUnexpectedEOF)
+	}
+
+	got, _ = CollectErrs(Take2(DecodeJSONArray[jsonEvent](json.NewDecoder(strings.NewReader(`[{"id": 1}, {"id": 2}, {"id": 3}]`))), 2), 0)
+	assert.Equal(t, []jsonEvent{{ID: 1}, {ID: 2}}, got)
+
+	pairs, errs := CollectErrs(DecodeJSONArray[[]int](json.NewDecoder(strings.NewReader(`[[1, 2], [3, 4]]`))), 0)
+	assert.Empty(t, errs)
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}}, pairs)
+}
+
+func TestDecodeJSONStream(t *testing.T) {
+	decode := func(input string) ([]jsonEvent, []error) {
+		return CollectErrs(DecodeJSONStream[jsonEvent](json.NewDecoder(strings.NewReader(input))), 0)
+	}
+
+	got, errs := decode("{\"id\": 1, \"kind\": \"a\"}\n{\"id\": 2, \"kind\": \"b\"}\n")
+	assert.Empty(t, errs)
+	assert.Equal(t, []jsonEvent{{1, "a"}, {2, "b"}}, got)
+
+	got, errs = decode("")
+	assert.Empty(t, errs)
+	assert.Empty(t, got)
+
+	got, errs = decode(`{"id": 1, "kind": "a"} {"id": "x"} {"id": 2, "kind": "b"}`)
+	assert.Equal(t, []jsonEvent{{1, "a"}, {2, "b"}}, got)
+	assert.Len(t, errs, 1)
+
+	got, errs = decode(`{"id": 1, "kind": "a"} garbage {"id": 2}`)
+	assert.Equal(t, []jsonEvent{{1, "a"}}, got)
+	assert.Len(t, errs, 1)
+
+	// a stream of arrays decodes each array whole rather than flattening it
+	pairs, errs := CollectErrs(DecodeJSONStream[[]int](json.NewDecoder(strings.NewReader("[1,2]\n[3,4]\n"))), 0)
+	assert.Empty(t, errs)
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}}, pairs)
+}
@@ -0,0 +1,49 @@
+package itertools
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Merge lazily merges sequences that are each sorted in ascending order into a single sorted sequence, holding only one value per input at a time
+func Merge[T cmp.Ordered](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return MergeFunc(cmp.Compare[T], seqs...)
+}
+
+// MergeFunc is [Merge] for sequences sorted by compare. Equal values are yielded in the order of the sequences they came from
+func MergeFunc[T any](compare func(a, b T) int, seqs ...iter.Seq[T]) iter.Seq[T] {
+	type head struct {
+		v   T
+		src int
+	}
+
+	return func(yield func(T) bool) {
+		nexts := make([]func() (T, bool), len(seqs))
+		h := &funcHeap[head]{less: func(a, b head) bool {
+			if c := compare(a.v, b.v); c != 0 {
+				return c < 0
+			}
+			return a.src < b.src
+		}}
+
+		for i, s := range seqs {
+			next, stop := iter.Pull(s)
+			defer stop()
+			nexts[i] = next
+
+			if v, ok := next(); ok {
+				h.push(head{v, i})
+			}
+		}
+
+		for h.Len() > 0 {
+			top := h.pop()
+			if !yield(top.v) {
+				return
+			}
+			if v, ok := nexts[top.src](); ok {
+				h.push(head{v, top.src})
+			}
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package itertools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	assertSequenceMatch(t,
+		Merge(NewSeq(1, 4, 7), NewSeq(2, 5, 8, 9), NewSeq[int](), NewSeq(3, 6)),
+		[]int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+	)
+	assertSequenceMatch(t, Merge[int](), []int{})
+	assertSequenceMatch(t, Take(Merge(Count(), Map(func(x int) int { return 2 * x }, Count())), 6), []int{0, 0, 1, 2, 2, 3})
+}
+
+func TestMergeFunc(t *testing.T) {
+	byLen := func(a, b string) int { return len(a) - len(b) }
+	assertSequenceMatch(t,
+		MergeFunc(byLen, NewSeq("a", "bb", "dddd"), NewSeq("A", "CCC"), NewSeq("BB")),
+		[]string{"a", "A", "bb", "BB", "CCC", "dddd"},
+	)
+
+	desc := func(a, b string) int { return strings.Compare(b, a) }
+	assertSequenceMatch(t, MergeFunc(desc, NewSeq("z", "m"), NewSeq("y", "a")), []string{"z", "y", "m", "a"})
+}
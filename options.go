@@ -0,0 +1,155 @@
+package itertools
+
+import (
+	"fmt"
+	"iter"
+	"sync"
+)
+
+// Option tunes how the slice-producing functions [Batched], [Combinations], [CombinationsWithReplacement], [Permutations], [ProductWith] and [ProductRepeat] allocate
+type Option func(*options)
+
+type options struct {
+	noCopy   bool
+	prealloc int
+	pool     any
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithNoCopy makes a function reuse a single slice for every value it yields instead of allocating a new one each time. Yielded slices are then only valid until the next iteration and must be copied to be retained
+func WithNoCopy() Option {
+	return func(o *options) {
+		o.noCopy = true
+	}
+}
+
+// WithPrealloc sets the initial capacity of slices that grow as values are appended to them, such as the batches built by [Batched], in place of their maximum size
+func WithPrealloc(n int) Option {
+	return func(o *options) {
+		o.prealloc = n
+	}
+}
+
+// OverflowPolicy decides what happens to the values of a group beyond the cap set by [WithMaxGroupSize]
+type OverflowPolicy int
+
+const (
+	// SpillOverflow starts a new group with the same key once a group is full
+	SpillOverflow OverflowPolicy = iota
+	// DropOverflow discards the rest of a group once it is full
+	DropOverflow
+	// ErrorOnOverflow stops grouping once a group is full. [TryGroupBy], [TryGroupByFunc] and [TryChunkWhile] then report an error wrapping [ErrGroupOverflow], while the other grouping functions just end
+	ErrorOnOverflow
+)
+
+// GroupOption tunes how the grouping functions [GroupBy], [GroupByFunc] and [ChunkWhile] bound their groups
+type GroupOption func(*groupOptions)
+
+type groupOptions struct {
+	maxGroup int
+	overflow OverflowPolicy
+}
+
+func newGroupOptions(opts []GroupOption) groupOptions {
+	var o groupOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithMaxGroupSize caps the groups built by [GroupBy], [GroupByFunc] and [ChunkWhile] at n values, protecting against keys that would otherwise buffer without bound. Values past the cap are handled according to policy, which defaults to [SpillOverflow]
+func WithMaxGroupSize(n int, policy ...OverflowPolicy) GroupOption {
+	return func(o *groupOptions) {
+		o.maxGroup = n
+		o.overflow = SpillOverflow
+		if len(policy) > 0 {
+			o.overflow = policy[0]
+		}
+	}
+}
+
+// overflows reports whether a group already holding size values is full
+func (o groupOptions) overflows(size int) bool {
+	return o.maxGroup > 0 && size >= o.maxGroup
+}
+
+// overflowError returns the error reported for a full group under [ErrorOnOverflow]
+func (o groupOptions) overflowError() error {
+	return fmt.Errorf("%w: more than %d values", ErrGroupOverflow, o.maxGroup)
+}
+
+// capacity returns the preallocation requested by the options, capped at limit, or limit if none was
+func (o options) capacity(limit int) int {
+	if o.prealloc > 0 {
+		return min(o.prealloc, limit)
+	}
+	return limit
+}
+
+// reuse returns buf emptied for reuse when copying is disabled, otherwise a new slice with capacity n, drawn from the pool if one was given
+func reuse[T any](o options, buf []T, n int) []T {
+	if o.noCopy && buf != nil {
+		return buf[:0]
+	}
+	if o.pool != nil {
+		p, ok := o.pool.(*SlicePool[T])
+		if !ok {
+			panic(fmt.Sprintf("itertools: WithPool given %T for a function yielding %T", o.pool, []T(nil)))
+		}
+		return p.Get(n)
+	}
+	return make([]T, 0, n)
+}
+
+// SlicePool recycles slices handed out by functions given [WithPool], cutting allocations when tuples are released once processed
+type SlicePool[T any] struct {
+	pool sync.Pool
+}
+
+// NewSlicePool returns an empty [SlicePool]
+func NewSlicePool[T any]() *SlicePool[T] {
+	return &SlicePool[T]{}
+}
+
+// Get returns an empty slice with capacity of at least n, reusing a released slice when possible
+func (p *SlicePool[T]) Get(n int) []T {
+	if v, ok := p.pool.Get().(*[]T); ok && cap(*v) >= n {
+		return (*v)[:0]
+	}
+	return make([]T, 0, n)
+}
+
+// Release returns s to the pool. s must not be used after it is released
+func (p *SlicePool[T]) Release(s []T) {
+	clear(s[:cap(s)])
+	s = s[:0]
+	p.pool.Put(&s)
+}
+
+// WithPool makes a function draw the slices it yields from p, for the caller to hand back with [SlicePool.Release] or [AutoRelease]. Iterating panics if the pool's element type does not match the function's
+func WithPool[T any](p *SlicePool[T]) Option {
+	return func(o *options) {
+		o.pool = p
+	}
+}
+
+// AutoRelease yields the slices of s, releasing each back to p once the consumer's loop body has finished with it. Slices must not be retained beyond their iteration
+func AutoRelease[T any](s iter.Seq[[]T], p *SlicePool[T]) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		for v := range s {
+			ok := yield(v)
+			p.Release(v)
+			if !ok {
+				return
+			}
+		}
+	}
+}
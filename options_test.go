@@ -0,0 +1,99 @@
+package itertools
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// collectShared copies each yielded slice, asserting they all share one backing array
+func collectShared[T any](t *testing.T, s func(func([]T) bool)) [][]T {
+	var out [][]T
+	var first *T
+	for v := range s {
+		if len(v) > 0 {
+			if first == nil {
+				first = &v[:1][0]
+			}
+			assert.Same(t, first, &v[:1][0])
+		}
+		out = append(out, slices.Clone(v))
+	}
+	return out
+}
+
+func TestWithNoCopy(t *testing.T) {
+	assert.Equal(t,
+		toSlice(Batched(NewSeq(1, 2, 3, 4, 5), 2)),
+		collectShared(t, Batched(NewSeq(1, 2, 3, 4, 5), 2, WithNoCopy())),
+	)
+	assert.Equal(t,
+		toSlice(Combinations([]int{1, 2, 3, 4}, 2)),
+		collectShared(t, Combinations([]int{1, 2, 3, 4}, 2, WithNoCopy())),
+	)
+	assert.Equal(t,
+		toSlice(CombinationsWithReplacement([]int{1, 2, 3}, 2)),
+		collectShared(t, CombinationsWithReplacement([]int{1, 2, 3}, 2, WithNoCopy())),
+	)
+	assert.Equal(t,
+		toSlice(Permutations([]int{1, 2, 3}, 2)),
+		collectShared(t, Permutations([]int{1, 2, 3}, 2, WithNoCopy())),
+	)
+	assert.Equal(t,
+		toSlice(Product([]int{1, 2}, []int{3, 4})),
+		collectShared(t, ProductWith([][]int{{1, 2}, {3, 4}}, WithNoCopy())),
+	)
+	assert.Equal(t,
+		toSlice(ProductRepeat([]int{0, 1}, 2)),
+		collectShared(t, ProductRepeat([]int{0, 1}, 2, WithNoCopy())),
+	)
+}
+
+func TestWithPrealloc(t *testing.T) {
+	for batch := range Batched(NewSeq(1, 2, 3), 1000, WithPrealloc(4)) {
+		assert.Equal(t, []int{1, 2, 3}, batch)
+		assert.Equal(t, 4, cap(batch))
+	}
+
+	for batch := range Batched(NewSeq(1, 2, 3), 2, WithPrealloc(10)) {
+		assert.Equal(t, 2, cap(batch))
+	}
+}
+
+func TestWithPool(t *testing.T) {
+	pool := NewSlicePool[int]()
+
+	var got [][]int
+	for c := range AutoRelease(Combinations([]int{1, 2, 3, 4}, 2, WithPool(pool)), pool) {
+		got = append(got, slices.Clone(c))
+	}
+	assert.Equal(t, toSlice(Combinations([]int{1, 2, 3, 4}, 2)), got)
+
+	got = nil
+	for b := range AutoRelease(Batched(NewSeq(1, 2, 3, 4, 5), 2, WithPool(pool)), pool) {
+		got = append(got, slices.Clone(b))
+	}
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, got)
+
+	released := pool.Get(3)
+	assert.Empty(t, released)
+	assert.GreaterOrEqual(t, cap(released), 3)
+
+	assert.Panics(t, func() {
+		for range Batched(NewSeq("a", "b"), 2, WithPool(pool)) {
+		}
+	})
+}
+
+func TestSlicePool(t *testing.T) {
+	pool := NewSlicePool[*int]()
+	x := 1
+
+	s := append(pool.Get(2), &x, &x)
+	pool.Release(s)
+	assert.Nil(t, s[0])
+
+	assert.Len(t, pool.Get(8), 0)
+	assert.GreaterOrEqual(t, cap(pool.Get(8)), 8)
+}
@@ -0,0 +1,308 @@
+package itertools
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// MapReduce splits s into chunks of chunkSize values, reduces each chunk with mapChunk on up to workers goroutines, and folds the partial results together with merge in chunk order. It returns the context's error if ctx is done before all chunks are processed
+func MapReduce[T any, A any](ctx context.Context, s iter.Seq[T], workers int, mapChunk func([]T) A, merge func(A, A) A, chunkSize int) (A, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type job struct {
+		i     int
+		chunk []T
+	}
+	type partial struct {
+		i   int
+		acc A
+	}
+
+	jobs := make(chan job)
+	partials := make(chan partial)
+
+	go func() {
+		defer close(jobs)
+		for i, chunk := range Enumerate(Batched(s, max(chunkSize, 1))) {
+			select {
+			case jobs <- job{i, chunk}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range max(workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				select {
+				case partials <- partial{j.i, mapChunk(j.chunk)}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(partials)
+	}()
+
+	var acc A
+	var next int
+	pending := make(map[int]A)
+	for p := range partials {
+		pending[p.i] = p.acc
+		for {
+			a, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+
+			if next == 0 {
+				acc = a
+			} else {
+				acc = merge(acc, a)
+			}
+			next++
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		var zero A
+		return zero, err
+	}
+	return acc, nil
+}
+
+// ProcessByKey calls fn for each value of s on up to workers goroutines, guaranteeing that values sharing a key are processed in order by the same goroutine. Keys are assigned to workers round-robin on first sight. The first error returned by fn, or the context's error if ctx is done before every value is handed out, stops processing and is returned
+func ProcessByKey[T any, K comparable](ctx context.Context, s iter.Seq[T], key func(T) K, workers int, fn func(T) error) error {
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workers = max(workers, 1)
+	var once sync.Once
+	var firstErr error
+
+	var wg sync.WaitGroup
+	queues := make([]chan T, workers)
+	for i := range queues {
+		queues[i] = make(chan T, 1)
+		wg.Add(1)
+		go func(queue <-chan T) {
+			defer wg.Done()
+			for v := range queue {
+				if err := fn(v); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+			}
+		}(queues[i])
+	}
+
+	assigned := make(map[K]int)
+	var cut bool
+feed:
+	for v := range s {
+		k := key(v)
+		w, ok := assigned[k]
+		if !ok {
+			w = len(assigned) % workers
+			assigned[k] = w
+		}
+
+		select {
+		case queues[w] <- v:
+		case <-ctx.Done():
+			cut = true
+			break feed
+		}
+	}
+
+	for _, queue := range queues {
+		close(queue)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if cut {
+		return parent.Err()
+	}
+	return nil
+}
+
+// OrderedStage applies fn to the values of s on a pool of workers goroutines, yielding each result with its index in the order the inputs arrived. At most workers results are held awaiting their turn, bounding memory however slow individual calls are. Once ctx is done the sequence ends early without reporting it, so check ctx.Err after the loop to tell a truncated stage from a finished one. Every goroutine has exited by the time the loop ends, calls already in flight running to completion first
+func OrderedStage[T any, U any](ctx context.Context, s iter.Seq2[int, T], workers int, fn func(T) U) iter.Seq2[int, U] {
+	return func(yield func(int, U) bool) {
+		var wg sync.WaitGroup
+		defer wg.Wait()
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		type job struct {
+			v      T
+			result chan<- U
+		}
+		type future struct {
+			i      int
+			result <-chan U
+		}
+
+		workers = max(workers, 1)
+		jobs := make(chan job)
+		pending := make(chan future, workers)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(jobs)
+			defer close(pending)
+			for i, v := range s {
+				result := make(chan U, 1)
+				select {
+				case pending <- future{i, result}:
+				case <-ctx.Done():
+					return
+				}
+				select {
+				case jobs <- job{v, result}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := range jobs {
+					j.result <- fn(j.v)
+				}
+			}()
+		}
+
+		for f := range pending {
+			if ctx.Err() != nil {
+				return
+			}
+
+			select {
+			case u := <-f.result:
+				if !yield(f.i, u) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// ParallelMap applies f to the values of s on a pool of workers goroutines, yielding the results in input order. It is [OrderedStage] without the indices, so it likewise ends silently once ctx is done and waits for calls in flight before returning. It suits mappers dominated by I/O such as per-value network calls
+func ParallelMap[T any, U any](ctx context.Context, workers int, f func(T) U, s iter.Seq[T]) iter.Seq[U] {
+	return Values(OrderedStage(ctx, Enumerate(s), workers, f))
+}
+
+// ParallelMapUnordered applies f to the values of s on a pool of workers goroutines, yielding each result as soon as it is ready regardless of input order. Breaking out of the loop, or ctx being done, stops further values from being handed to workers. Calls already in flight run to completion and their results are discarded before the loop ends
+func ParallelMapUnordered[T any, U any](ctx context.Context, workers int, f func(T) U, s iter.Seq[T]) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		var wg sync.WaitGroup
+		defer wg.Wait()
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		jobs := make(chan T)
+		results := make(chan U)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(jobs)
+			for v := range s {
+				select {
+				case jobs <- v:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		var running sync.WaitGroup
+		for range max(workers, 1) {
+			running.Add(1)
+			go func() {
+				defer running.Done()
+				for v := range jobs {
+					select {
+					case results <- f(v):
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			running.Wait()
+			close(results)
+		}()
+
+		for u := range results {
+			if ctx.Err() != nil || !yield(u) {
+				return
+			}
+		}
+	}
+}
+
+// ParallelForEach calls f for each value of s on up to workers goroutines, returning once every call has finished. If ctx is done before every value has been handed out, the rest are skipped and the context's error is returned
+func ParallelForEach[T any](ctx context.Context, workers int, f func(T), s iter.Seq[T]) error {
+	jobs := make(chan T)
+
+	var wg sync.WaitGroup
+	for range max(workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range jobs {
+				f(v)
+			}
+		}()
+	}
+
+	var err error
+feed:
+	for v := range s {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		select {
+		case jobs <- v:
+		case <-ctx.Done():
+			err = ctx.Err()
+			break feed
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+	return err
+}
@@ -0,0 +1,216 @@
+package itertools
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapReduce(t *testing.T) {
+	sumChunk := func(xs []int) int {
+		var total int
+		for _, x := range xs {
+			total += x
+		}
+		return total
+	}
+	add := func(a, b int) int { return a + b }
+
+	total, err := MapReduce(context.Background(), Take(Count(), 1000), 4, sumChunk, add, 64)
+	assert.NoError(t, err)
+	assert.Equal(t, 499500, total)
+
+	joined, err := MapReduce(context.Background(), NewSeq(strings.Split("abcdefghij", "")...), 3,
+		func(xs []string) string { return strings.Join(xs, "") },
+		func(a, b string) string { return a + b },
+		3,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "abcdefghij", joined)
+
+	empty, err := MapReduce(context.Background(), NewSeq[int](), 2, sumChunk, add, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, empty)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = MapReduce(ctx, Count(), 2, sumChunk, add, 10)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestProcessByKey(t *testing.T) {
+	var mu sync.Mutex
+	seen := make(map[int][]int)
+
+	err := ProcessByKey(context.Background(), Take(Count(), 100), func(x int) int { return x % 7 }, 3, func(x int) error {
+		mu.Lock()
+		defer mu.Unlock()
+		seen[x%7] = append(seen[x%7], x)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, seen, 7)
+	for k, vals := range seen {
+		assert.Equal(t, toSlice(Take(Map(func(i int) int { return k + 7*i }, Count()), len(vals))), vals)
+	}
+
+	err = ProcessByKey(context.Background(), Count(), func(x int) int { return x % 2 }, 2, func(x int) error {
+		if x == 10 {
+			return errTest
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, errTest)
+
+	// cancelling once every value has been handed out is not an error
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err = ProcessByKey(ctx, Take(Count(), 5), func(x int) int { return x }, 2, func(x int) error {
+		if x == 4 {
+			cancel()
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestOrderedStage(t *testing.T) {
+	slowFirst := func(x int) int {
+		if x%4 == 0 {
+			time.Sleep(2 * time.Millisecond)
+		}
+		return x * x
+	}
+
+	sparse := func(yield func(int, int) bool) {
+		for i, v := range Enumerate(Take(Count(), 12)) {
+			if i%3 != 1 && !yield(i, v) {
+				return
+			}
+		}
+	}
+	keys, vals := toSlice2(OrderedStage(context.Background(), sparse, 4, slowFirst))
+	assert.Equal(t, []int{0, 2, 3, 5, 6, 8, 9, 11}, keys)
+	assert.Equal(t, []int{0, 4, 9, 25, 36, 64, 81, 121}, vals)
+
+	var active atomic.Int32
+	tracked := func(x int) int {
+		active.Add(1)
+		defer active.Add(-1)
+		return slowFirst(x)
+	}
+	keys, _ = toSlice2(TakeWhile2(func(i, _ int) bool { return i < 3 }, OrderedStage(context.Background(), Enumerate(Count()), 3, tracked)))
+	assert.Equal(t, []int{0, 1, 2}, keys)
+	assert.Zero(t, active.Load())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	keys, _ = toSlice2(OrderedStage(ctx, Enumerate(Count()), 2, slowFirst))
+	assert.Empty(t, keys)
+}
+
+func TestParallelMap(t *testing.T) {
+	var active, peak atomic.Int32
+	slow := func(x int) string {
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Duration(5-x%5) * time.Millisecond)
+		return strconv.Itoa(x)
+	}
+
+	got := toSlice(ParallelMap(context.Background(), 4, slow, Take(Count(), 10)))
+	assert.Equal(t, []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}, got)
+	assert.LessOrEqual(t, peak.Load(), int32(4))
+	assert.Greater(t, peak.Load(), int32(1))
+
+	assertSequenceMatch(t, Take(ParallelMap(context.Background(), 2, slow, Count()), 3), []string{"0", "1", "2"})
+	assert.Zero(t, active.Load())
+}
+
+func TestParallelMapUnordered(t *testing.T) {
+	release := make(chan struct{})
+	blockFirst := func(x int) int {
+		if x == 0 {
+			<-release
+		}
+		return x * x
+	}
+
+	var got []int
+	for v := range ParallelMapUnordered(context.Background(), 3, blockFirst, Take(Count(), 6)) {
+		got = append(got, v)
+		if len(got) == 5 {
+			close(release)
+		}
+	}
+	assert.Equal(t, 0, got[5])
+	assert.ElementsMatch(t, []int{0, 1, 4, 9, 16, 25}, got)
+
+	var started atomic.Int32
+	counted := func(x int) int {
+		started.Add(1)
+		return x
+	}
+	assert.Len(t, toSlice(Take(ParallelMapUnordered(context.Background(), 2, counted, Count()), 5)), 5)
+	assert.Less(t, started.Load(), int32(10))
+
+	var active atomic.Int32
+	tracked := func(x int) int {
+		active.Add(1)
+		defer active.Add(-1)
+		if x == 0 {
+			time.Sleep(5 * time.Millisecond)
+		}
+		return x * x
+	}
+	assert.Len(t, toSlice(Take(ParallelMapUnordered(context.Background(), 3, tracked, Count()), 2)), 2)
+	assert.Zero(t, active.Load())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assertSequenceMatch(t, ParallelMapUnordered(ctx, 2, counted, Count()), []int{})
+}
+
+func TestParallelForEach(t *testing.T) {
+	var mu sync.Mutex
+	var seen []int
+	record := func(x int) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, x)
+	}
+
+	assert.NoError(t, ParallelForEach(context.Background(), 3, record, Take(Count(), 20)))
+	assert.ElementsMatch(t, toSlice(Take(Count(), 20)), seen)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	seen = nil
+	stopAt5 := func(x int) {
+		record(x)
+		if x == 5 {
+			cancel()
+		}
+	}
+	err := ParallelForEach(ctx, 1, stopAt5, Count())
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.LessOrEqual(t, len(seen), 7)
+
+	// cancelling once every value has been handed out is not an error
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	seen = nil
+	assert.NoError(t, ParallelForEach(ctx, 2, stopAt5, Take(Count(), 6)))
+	assert.Len(t, seen, 6)
+}
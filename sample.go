@@ -0,0 +1,68 @@
+package itertools
+
+import (
+	"iter"
+	"time"
+)
+
+// SamplePerKey yields at most n values of s for each distinct key, dropping the rest. It is equivalent to [LimitPerGroup]
+//
+// Deprecated: use [LimitPerGroup]
+func SamplePerKey[T any, K comparable](s iter.Seq[T], key func(T) K, n int) iter.Seq[T] {
+	return LimitPerGroup(s, key, n)
+}
+
+// LimitPerGroup yields only the first n values of s in each group identified by key, in a single lazy pass, like filtering on SQL's ROW_NUMBER() <= n
+func LimitPerGroup[T any, K comparable](s iter.Seq[T], key func(T) K, n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		counts := make(map[K]int)
+		for v := range s {
+			k := key(v)
+			if counts[k] >= n {
+				continue
+			}
+			counts[k]++
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// refill adds the tokens earned at rate per second since the last refill, up to burst
+func (b *bucket) refill(now time.Time, rate float64, burst int) {
+	b.tokens = min(float64(burst), b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+}
+
+// SamplePerKeyRate yields values of the time-ordered sequence s through a token bucket per key, refilled at rate tokens per second of ts time and holding at most burst tokens
+func SamplePerKeyRate[T any, K comparable](s iter.Seq[T], key func(T) K, ts func(T) time.Time, rate float64, burst int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		buckets := make(map[K]*bucket)
+		for v := range s {
+			k, now := key(v), ts(v)
+
+			b, ok := buckets[k]
+			if !ok {
+				b = &bucket{tokens: float64(burst), last: now}
+				buckets[k] = b
+			}
+
+			b.refill(now, rate, burst)
+			if b.tokens < 1 {
+				continue
+			}
+			b.tokens--
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
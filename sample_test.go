@@ -0,0 +1,53 @@
+package itertools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSamplePerKey(t *testing.T) {
+	firstChar := func(s string) byte { return s[0] }
+
+	assertSequenceMatch(t,
+		SamplePerKey(NewSeq("a1", "a2", "b1", "a3", "c1", "b2", "b3"), firstChar, 2),
+		[]string{"a1", "a2", "b1", "c1", "b2"},
+	)
+}
+
+func TestLimitPerGroup(t *testing.T) {
+	type row struct {
+		dept string
+		name string
+	}
+	rows := NewSeq(row{"eng", "a"}, row{"ops", "b"}, row{"eng", "c"}, row{"eng", "d"}, row{"ops", "e"}, row{"ops", "f"})
+	dept := func(r row) string { return r.dept }
+
+	assertSequenceMatch(t, LimitPerGroup(rows, dept, 1), []row{{"eng", "a"}, {"ops", "b"}})
+	assertSequenceMatch(t, LimitPerGroup(rows, dept, 2), []row{{"eng", "a"}, {"ops", "b"}, {"eng", "c"}, {"ops", "e"}})
+	assertSequenceMatch(t, LimitPerGroup(rows, dept, 0), []row{})
+}
+
+func TestSamplePerKeyRate(t *testing.T) {
+	type event struct {
+		key string
+		at  int
+	}
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	key := func(e event) string { return e.key }
+	ts := func(e event) time.Time { return base.Add(time.Duration(e.at) * time.Second) }
+
+	assertSequenceMatch(t,
+		SamplePerKeyRate(NewSeq(
+			event{"a", 0},
+			event{"a", 0},
+			event{"a", 0},
+			event{"b", 0},
+			event{"a", 1},
+			event{"a", 1},
+			event{"a", 4},
+			event{"a", 4},
+			event{"a", 4},
+		), key, ts, 1, 2),
+		[]event{{"a", 0}, {"a", 0}, {"b", 0}, {"a", 1}, {"a", 4}, {"a", 4}},
+	)
+}
@@ -0,0 +1,79 @@
+package itertools
+
+import "iter"
+
+// Keys yields the keys of s
+func Keys[K any, V any](s iter.Seq2[K, V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range s {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values yields the values of s
+func Values[K any, V any](s iter.Seq2[K, V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Swap yields the pairs of s with keys and values exchanged
+func Swap[K any, V any](s iter.Seq2[K, V]) iter.Seq2[V, K] {
+	return func(yield func(V, K) bool) {
+		for k, v := range s {
+			if !yield(v, k) {
+				return
+			}
+		}
+	}
+}
+
+// Filter2 is [Filter] for an [iter.Seq2], yielding only the pairs for which pred(k, v) returns true
+func Filter2[K any, V any](pred func(K, V) bool, s iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range s {
+			if pred(k, v) && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Take2 is [Take] for an [iter.Seq2], yielding at most the first n pairs of s
+func Take2[K any, V any](s iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if n <= 0 {
+			return
+		}
+		var i int
+		for k, v := range s {
+			if !yield(k, v) {
+				return
+			}
+			i++
+			if i >= n {
+				return
+			}
+		}
+	}
+}
+
+// Chain2 is [Chain] for an [iter.Seq2], yielding the pairs of each sequence in turn
+func Chain2[K any, V any](seqs ...iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, seq := range seqs {
+			for k, v := range seq {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package itertools
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeysValues(t *testing.T) {
+	assertSequenceMatch(t, Keys(Enumerate(NewSeq("a", "b", "c"))), []int{0, 1, 2})
+	assertSequenceMatch(t, Values(Enumerate(NewSeq("a", "b", "c"))), []string{"a", "b", "c"})
+	assertSequenceMatch(t, Take(Keys(Enumerate(Count())), 2), []int{0, 1})
+	assertSequenceMatch(t, Take(Values(Enumerate(Count())), 2), []int{0, 1})
+}
+
+func TestSwap(t *testing.T) {
+	keys, vals := toSlice2(Swap(Enumerate(NewSeq("a", "b"))))
+	assert.Equal(t, []string{"a", "b"}, keys)
+	assert.Equal(t, []int{0, 1}, vals)
+
+	assert.Equal(t, map[int]string{1: "a", 2: "b"}, maps.Collect(Swap(maps.All(map[string]int{"a": 1, "b": 2}))))
+}
+
+func TestFilter2(t *testing.T) {
+	keys, vals := toSlice2(Filter2(func(i int, s string) bool { return i%2 == 0 || s == "x" }, Enumerate(NewSeq("a", "b", "c", "x", "e"))))
+	assert.Equal(t, []int{0, 2, 3, 4}, keys)
+	assert.Equal(t, []string{"a", "c", "x", "e"}, vals)
+
+	keys, _ = toSlice2(Take2(Filter2(func(i, _ int) bool { return i%3 == 0 }, Enumerate(Count())), 3))
+	assert.Equal(t, []int{0, 3, 6}, keys)
+}
+
+func TestTake2(t *testing.T) {
+	keys, vals := toSlice2(Take2(Enumerate(NewSeq("a", "b", "c")), 2))
+	assert.Equal(t, []int{0, 1}, keys)
+	assert.Equal(t, []string{"a", "b"}, vals)
+
+	keys, _ = toSlice2(Take2(Enumerate(NewSeq("a")), 5))
+	assert.Equal(t, []int{0}, keys)
+
+	keys, _ = toSlice2(Take2(Enumerate(Count()), 0))
+	assert.Empty(t, keys)
+}
+
+func TestChain2(t *testing.T) {
+	keys, vals := toSlice2(Chain2(Enumerate(NewSeq("a", "b")), Enumerate(NewSeq("c"))))
+	assert.Equal(t, []int{0, 1, 0}, keys)
+	assert.Equal(t, []string{"a", "b", "c"}, vals)
+
+	keys, _ = toSlice2(Take2(Chain2(Enumerate(NewSeq(7)), Enumerate(Count())), 3))
+	assert.Equal(t, []int{0, 0, 1}, keys)
+}
@@ -0,0 +1,73 @@
+package itertools
+
+import (
+	"errors"
+	"iter"
+)
+
+// Sink is a destination that stages written values until they are flushed, such as a buffered writer or a transactional batch insert
+type Sink[T any] interface {
+	Write(T) error
+	// Flush commits the values written so far
+	Flush() error
+	Close() error
+}
+
+// Drain writes the values of s to sink until the first error, flushing every flushEvery writes and at the end, and always closes the sink
+func Drain[T any](s iter.Seq2[T, error], sink Sink[T], flushEvery ...int) (err error) {
+	defer func() {
+		err = errors.Join(err, sink.Close())
+	}()
+
+	every := 0
+	if len(flushEvery) > 0 {
+		every = flushEvery[0]
+	}
+
+	var unflushed int
+	for v, err := range s {
+		if err != nil {
+			return err
+		}
+		if err := sink.Write(v); err != nil {
+			return err
+		}
+
+		unflushed++
+		if unflushed == every {
+			if err := sink.Flush(); err != nil {
+				return err
+			}
+			unflushed = 0
+		}
+	}
+
+	if unflushed > 0 {
+		return sink.Flush()
+	}
+	return nil
+}
+
+// Route sends values matching a predicate to a consumer, for use with [Demux]
+type Route[T any] struct {
+	// Match selects the values for this route. A nil Match accepts every value, making the route a default
+	Match func(T) bool
+	// Handle consumes each matched value
+	Handle func(T) error
+}
+
+// Demux sends each value of s to the first of routes whose Match accepts it, dropping values no route accepts. The first error from a Handle stops routing and is returned
+func Demux[T any](s iter.Seq[T], routes []Route[T]) error {
+	for v := range s {
+		for _, r := range routes {
+			if r.Match != nil && !r.Match(v) {
+				continue
+			}
+			if err := r.Handle(v); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return nil
+}
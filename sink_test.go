@@ -0,0 +1,96 @@
+package itertools
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingSink struct {
+	staged    []int
+	committed [][]int
+	closed    bool
+	failWrite int
+	closeErr  error
+}
+
+func (s *recordingSink) Write(v int) error {
+	if v == s.failWrite {
+		return errTest
+	}
+	s.staged = append(s.staged, v)
+	return nil
+}
+
+func (s *recordingSink) Flush() error {
+	s.committed = append(s.committed, s.staged)
+	s.staged = nil
+	return nil
+}
+
+func (s *recordingSink) Close() error {
+	s.closed = true
+	return s.closeErr
+}
+
+func TestDrain(t *testing.T) {
+	sink := &recordingSink{failWrite: -1}
+	assert.NoError(t, Drain(fallible(1, 2, 3), sink))
+	assert.Equal(t, [][]int{{1, 2, 3}}, sink.committed)
+	assert.True(t, sink.closed)
+
+	sink = &recordingSink{failWrite: -1}
+	assert.NoError(t, Drain(fallible(1, 2, 3, 4, 5), sink, 2))
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, sink.committed)
+
+	sink = &recordingSink{failWrite: -1}
+	assert.NoError(t, Drain(fallible(1, 2, 3, 4), sink, 2))
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}}, sink.committed)
+
+	sink = &recordingSink{failWrite: -1}
+	assert.EqualError(t, Drain(fallible(1, 2, -3, 4), sink, 2), "bad value -3")
+	assert.Equal(t, [][]int{{1, 2}}, sink.committed)
+	assert.True(t, sink.closed)
+
+	closeErr := errors.New("close failed")
+	sink = &recordingSink{failWrite: 3, closeErr: closeErr}
+	err := Drain(fallible(1, 2, 3), sink)
+	assert.ErrorIs(t, err, errTest)
+	assert.ErrorIs(t, err, closeErr)
+	assert.Empty(t, sink.committed)
+
+	sink = &recordingSink{failWrite: -1}
+	assert.Panics(t, func() {
+		_ = Drain(Map2(func(v int, err error) (int, error) { panic("boom") }, fallible(1)), sink)
+	})
+	assert.True(t, sink.closed)
+}
+
+func TestDemux(t *testing.T) {
+	var small, even, rest []int
+	collect := func(dst *[]int) func(int) error {
+		return func(v int) error {
+			*dst = append(*dst, v)
+			return nil
+		}
+	}
+
+	err := Demux(NewSeq(1, 2, 3, 4, 5, 6, 7, 8), []Route[int]{
+		{Match: func(x int) bool { return x < 3 }, Handle: collect(&small)},
+		{Match: isEven, Handle: collect(&even)},
+		{Handle: collect(&rest)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, small)
+	assert.Equal(t, []int{4, 6, 8}, even)
+	assert.Equal(t, []int{3, 5, 7}, rest)
+
+	even = nil
+	err = Demux(Count(), []Route[int]{
+		{Match: isEven, Handle: collect(&even)},
+		{Match: func(x int) bool { return x == 5 }, Handle: func(int) error { return errTest }},
+	})
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, []int{0, 2, 4}, even)
+}
@@ -0,0 +1,39 @@
+package itertools
+
+import (
+	"cmp"
+	"container/heap"
+	"iter"
+)
+
+// Sorted yields the values of s in ascending order. The whole of s is buffered first, but values are then ordered lazily, so taking only the first k of n values costs O(n + k log n) rather than a full sort
+func Sorted[T cmp.Ordered](s iter.Seq[T]) iter.Seq[T] {
+	return SortedFunc(s, cmp.Compare[T])
+}
+
+// SortedFunc is [Sorted] for values ordered by compare. The sort is stable, keeping equal values in the order they arrived
+func SortedFunc[T any](s iter.Seq[T], compare func(a, b T) int) iter.Seq[T] {
+	type entry struct {
+		v T
+		i int
+	}
+
+	return func(yield func(T) bool) {
+		h := &funcHeap[entry]{less: func(a, b entry) bool {
+			if c := compare(a.v, b.v); c != 0 {
+				return c < 0
+			}
+			return a.i < b.i
+		}}
+		for i, v := range Enumerate(s) {
+			h.items = append(h.items, entry{v, i})
+		}
+		heap.Init(h)
+
+		for h.Len() > 0 {
+			if !yield(h.pop().v) {
+				return
+			}
+		}
+	}
+}
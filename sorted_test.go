@@ -0,0 +1,29 @@
+package itertools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSorted(t *testing.T) {
+	assertSequenceMatch(t, Sorted(NewSeq(5, 2, 8, 1, 9, 2)), []int{1, 2, 2, 5, 8, 9})
+	assertSequenceMatch(t, Sorted(NewSeq[string]()), []string{})
+	assertSequenceMatch(t, Take(Sorted(NewSeq("pear", "fig", "apple", "kiwi")), 2), []string{"apple", "fig"})
+}
+
+func TestSortedFunc(t *testing.T) {
+	byLen := func(a, b string) int { return len(a) - len(b) }
+	assertSequenceMatch(t,
+		SortedFunc(NewSeq("ccc", "a", "bb", "b", "dd", "c"), byLen),
+		[]string{"a", "b", "c", "bb", "dd", "ccc"},
+	)
+
+	var compared int
+	counting := func(a, b int) int {
+		compared++
+		return a - b
+	}
+	assertSequenceMatch(t, Take(SortedFunc(Take(Count(), 1000), counting), 3), []int{0, 1, 2})
+	assert.Less(t, compared, 3000)
+}
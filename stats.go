@@ -0,0 +1,42 @@
+package itertools
+
+import (
+	"iter"
+	"slices"
+)
+
+// Normalize yields each value of s divided by the total of all values, so the results sum to 1. The values are buffered to find the total before anything is yielded, and a zero total yields NaN or infinities as with the / operator
+func Normalize(s iter.Seq[float64]) iter.Seq[float64] {
+	return func(yield func(float64) bool) {
+		vals, total := sumAll(s)
+		for _, v := range vals {
+			if !yield(v / total) {
+				return
+			}
+		}
+	}
+}
+
+// CumulativeFraction yields the running total of s as a fraction of the total of all values, ending at 1. The values are buffered as in [Normalize]
+func CumulativeFraction(s iter.Seq[float64]) iter.Seq[float64] {
+	return func(yield func(float64) bool) {
+		vals, total := sumAll(s)
+		var running float64
+		for _, v := range vals {
+			running += v
+			if !yield(running / total) {
+				return
+			}
+		}
+	}
+}
+
+// sumAll collects the values of s along with their total
+func sumAll(s iter.Seq[float64]) ([]float64, float64) {
+	vals := slices.Collect(s)
+	var total float64
+	for _, v := range vals {
+		total += v
+	}
+	return vals, total
+}
@@ -0,0 +1,23 @@
+package itertools
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	assertSequenceMatch(t, Normalize(NewSeq(1.0, 3.0, 4.0)), []float64{0.125, 0.375, 0.5})
+	assertSequenceMatch(t, Normalize(NewSeq[float64]()), []float64{})
+	assertSequenceMatch(t, Take(Normalize(NewSeq(2.0, 2.0)), 1), []float64{0.5})
+
+	for v := range Normalize(NewSeq(0.0, 0.0)) {
+		assert.True(t, math.IsNaN(v))
+	}
+}
+
+func TestCumulativeFraction(t *testing.T) {
+	assertSequenceMatch(t, CumulativeFraction(NewSeq(1.0, 3.0, 4.0)), []float64{0.125, 0.5, 1})
+	assertSequenceMatch(t, CumulativeFraction(NewSeq[float64]()), []float64{})
+}
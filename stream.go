@@ -0,0 +1,88 @@
+package itertools
+
+import "iter"
+
+// Stream wraps an [iter.Seq] with chainable methods, so pipelines read left to right while staying lazy. A Stream can be ranged over directly
+type Stream[T any] iter.Seq[T]
+
+// NewStream wraps s in a [Stream]
+func NewStream[T any](s iter.Seq[T]) Stream[T] {
+	return Stream[T](s)
+}
+
+// MapStream applies f to each value of s, changing the element type, which methods cannot do
+func MapStream[T any, U any](s Stream[T], f func(T) U) Stream[U] {
+	return Stream[U](Map(f, s.Seq()))
+}
+
+// Seq unwraps the stream into an [iter.Seq]
+func (s Stream[T]) Seq() iter.Seq[T] {
+	return iter.Seq[T](s)
+}
+
+// Map applies f to each value, see [Map]. Use [MapStream] to change the element type
+func (s Stream[T]) Map(f func(T) T) Stream[T] {
+	return Stream[T](Map(f, s.Seq()))
+}
+
+// Filter keeps values for which pred returns true, see [Filter]
+func (s Stream[T]) Filter(pred func(T) bool) Stream[T] {
+	return Stream[T](Filter(pred, s.Seq()))
+}
+
+// FilterFalse keeps values for which pred returns false, see [FilterFalse]
+func (s Stream[T]) FilterFalse(pred func(T) bool) Stream[T] {
+	return Stream[T](FilterFalse(pred, s.Seq()))
+}
+
+// Take keeps the first n values, see [Take]
+func (s Stream[T]) Take(n int) Stream[T] {
+	return Stream[T](Take(s.Seq(), n))
+}
+
+// TakeWhile keeps values until pred first returns false, see [TakeWhile]
+func (s Stream[T]) TakeWhile(pred func(T) bool) Stream[T] {
+	return Stream[T](TakeWhile(pred, s.Seq()))
+}
+
+// DropWhile skips values until pred first returns false, see [DropWhile]
+func (s Stream[T]) DropWhile(pred func(T) bool) Stream[T] {
+	return Stream[T](DropWhile(pred, s.Seq()))
+}
+
+// Slice keeps values with positions in [start, end), see [Slice]
+func (s Stream[T]) Slice(start, end int) Stream[T] {
+	return Stream[T](Slice(s.Seq(), start, end))
+}
+
+// Chain appends the values of others, see [Chain]
+func (s Stream[T]) Chain(others ...iter.Seq[T]) Stream[T] {
+	return Stream[T](Chain(append([]iter.Seq[T]{s.Seq()}, others...)...))
+}
+
+// Batched groups values into slices of n, see [Batched]. It returns a plain sequence since a method cannot return a Stream of a different element type; wrap it with [NewStream] to keep chaining
+func (s Stream[T]) Batched(n int, opts ...Option) iter.Seq[[]T] {
+	return Batched(s.Seq(), n, opts...)
+}
+
+// Enumerate pairs each value with its index, see [Enumerate]
+func (s Stream[T]) Enumerate() iter.Seq2[int, T] {
+	return Enumerate(s.Seq())
+}
+
+// Collect gathers the values into a slice, see [ToSlice]
+func (s Stream[T]) Collect() []T {
+	return ToSlice(s.Seq())
+}
+
+// ForEach calls fn with each value
+func (s Stream[T]) ForEach(fn func(T)) {
+	for v := range s {
+		fn(v)
+	}
+}
+
+// Reduce folds the values together using the first as the initial result, see [ReduceFirst]
+func (s Stream[T]) Reduce(f func(T, T) T) (T, bool) {
+	return ReduceFirst(s.Seq(), f)
+}
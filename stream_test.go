@@ -0,0 +1,54 @@
+package itertools
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream(t *testing.T) {
+	got := NewStream(Count()).
+		Filter(isEven).
+		Map(square).
+		DropWhile(func(x int) bool { return x < 10 }).
+		Take(3).
+		Collect()
+	assert.Equal(t, []int{16, 36, 64}, got)
+
+	assert.Equal(t,
+		[]int{3, 4, 1, 2},
+		NewStream(NewSeq(1, 2, 3, 4)).Slice(2, -1).Chain(NewSeq(1, 2)).Collect(),
+	)
+
+	assert.Equal(t,
+		[]int{1, 3},
+		NewStream(NewSeq(1, 2, 3, 4)).FilterFalse(isEven).TakeWhile(func(x int) bool { return x < 5 }).Collect(),
+	)
+
+	assert.Equal(t, [][]int{{0, 1}, {2}}, ToSlice(NewStream(Count()).Take(3).Batched(2)))
+
+	total, ok := NewStream(NewSeq(1, 2, 3)).Reduce(func(a, b int) int { return a + b })
+	assert.True(t, ok)
+	assert.Equal(t, 6, total)
+
+	var seen []int
+	for i, v := range NewStream(NewSeq(5, 6)).Enumerate() {
+		seen = append(seen, i, v)
+	}
+	assert.Equal(t, []int{0, 5, 1, 6}, seen)
+
+	seen = nil
+	NewStream(NewSeq(1, 2)).ForEach(func(x int) { seen = append(seen, x) })
+	assert.Equal(t, []int{1, 2}, seen)
+}
+
+func TestMapStream(t *testing.T) {
+	assertSequenceMatch(t, MapStream(NewStream(Count()).Take(3), strconv.Itoa).Seq(), []string{"0", "1", "2"})
+
+	var ranged []string
+	for s := range MapStream(NewStream(NewSeq(7)), strconv.Itoa) {
+		ranged = append(ranged, s)
+	}
+	assert.Equal(t, []string{"7"}, ranged)
+}
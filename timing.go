@@ -0,0 +1,124 @@
+package itertools
+
+import (
+	"iter"
+	"time"
+)
+
+// DetectSlow yields the values of s, calling onSlow with any value that took upstream stages longer than threshold to produce. Time spent by the consumer's own loop body is not counted, and a nil clock means [SystemClock]
+func DetectSlow[T any](s iter.Seq[T], threshold time.Duration, onSlow func(T, time.Duration), clock Clock) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		c := clockOrSystem(clock)
+		start := c.Now()
+		for v := range s {
+			if elapsed := c.Now().Sub(start); elapsed > threshold {
+				onSlow(v, elapsed)
+			}
+			if !yield(v) {
+				return
+			}
+			start = c.Now()
+		}
+	}
+}
+
+// LogSample yields the values of s unchanged, calling logf with the index and value of every everyN-th one starting with the first, for cheap visibility into high-volume pipelines. An everyN below 1 logs every value
+func LogSample[T any](s iter.Seq[T], everyN int, logf func(int, T)) iter.Seq[T] {
+	everyN = max(everyN, 1)
+	return func(yield func(T) bool) {
+		var i int
+		for v := range s {
+			if i%everyN == 0 {
+				logf(i, v)
+			}
+			if !yield(v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Delay yields the values of s after first waiting d. A nil clock means [SystemClock]
+func Delay[T any](s iter.Seq[T], d time.Duration, clock Clock) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		clockOrSystem(clock).Sleep(d)
+		for v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Spread paces the values of the finite sequence s evenly over total, yielding the first at once and the last once total has elapsed. The values are buffered to count them before the first is yielded, and time spent by the consumer counts towards each wait. A nil clock means [SystemClock]
+func Spread[T any](s iter.Seq[T], total time.Duration, clock Clock) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		c := clockOrSystem(clock)
+		vals := ToSlice(s)
+
+		var interval time.Duration
+		if len(vals) > 1 {
+			interval = total / time.Duration(len(vals)-1)
+		}
+
+		start := c.Now()
+		for i, v := range vals {
+			if wait := start.Add(time.Duration(i) * interval).Sub(c.Now()); wait > 0 {
+				c.Sleep(wait)
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ReplayTimed yields the values of s spaced like their timestamps divided by speed, or without waiting if speed is not positive. A nil clock means [SystemClock]
+func ReplayTimed[T any](s iter.Seq[T], ts func(T) time.Time, speed float64, clock Clock) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		c := clockOrSystem(clock)
+
+		var start, first time.Time
+		var started bool
+		for v := range s {
+			if !started {
+				start, first, started = c.Now(), ts(v), true
+			} else if speed > 0 {
+				offset := time.Duration(float64(ts(v).Sub(first)) / speed)
+				if wait := start.Add(offset).Sub(c.Now()); wait > 0 {
+					c.Sleep(wait)
+				}
+			}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// RateLimit yields the values of s at most r per second with bursts of up to burst values, or unthrottled if r is not positive. A nil clock means [SystemClock]
+func RateLimit[T any](s iter.Seq[T], r float64, burst int, clock Clock) iter.Seq[T] {
+	if r <= 0 {
+		return s
+	}
+	burst = max(burst, 1)
+
+	return func(yield func(T) bool) {
+		c := clockOrSystem(clock)
+		b := bucket{tokens: float64(burst), last: c.Now()}
+		for v := range s {
+			b.refill(c.Now(), r, burst)
+			if b.tokens < 1 {
+				c.Sleep(time.Duration((1 - b.tokens) / r * float64(time.Second)))
+				b.refill(c.Now(), r, burst)
+			}
+			b.tokens--
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package itertools
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/astonm/go-itertools/ittest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectSlow(t *testing.T) {
+	c := ittest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	src := Map(func(x int) int {
+		if x == 2 {
+			c.Sleep(20 * time.Second)
+		}
+		return x
+	}, NewSeq(1, 2, 3))
+
+	var slow []int
+	var took []time.Duration
+	onSlow := func(v int, d time.Duration) {
+		slow = append(slow, v)
+		took = append(took, d)
+	}
+	for v := range DetectSlow(src, 10*time.Second, onSlow, c) {
+		if v == 1 {
+			// slow consumers are not blamed on the source
+			c.Sleep(20 * time.Second)
+		}
+	}
+	assert.Equal(t, []int{2}, slow)
+	assert.Equal(t, []time.Duration{20 * time.Second}, took)
+}
+
+func TestLogSample(t *testing.T) {
+	var logged []string
+	logf := func(i int, v string) { logged = append(logged, fmt.Sprintf("%d=%s", i, v)) }
+
+	assertSequenceMatch(t, LogSample(NewSeq("a", "b", "c", "d", "e"), 2, logf), []string{"a", "b", "c", "d", "e"})
+	assert.Equal(t, []string{"0=a", "2=c", "4=e"}, logged)
+
+	logged = nil
+	assertSequenceMatch(t, LogSample(NewSeq("a", "b"), 0, logf), []string{"a", "b"})
+	assert.Equal(t, []string{"0=a", "1=b"}, logged)
+}
+
+func TestDelay(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := ittest.NewFakeClock(start)
+
+	var at []time.Duration
+	for range Delay(NewSeq(1, 2), time.Minute, c) {
+		at = append(at, c.Now().Sub(start))
+	}
+	assert.Equal(t, []time.Duration{time.Minute, time.Minute}, at)
+}
+
+func TestSpread(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := ittest.NewFakeClock(start)
+
+	var at []time.Duration
+	for range Spread(NewSeq(1, 2, 3, 4, 5), time.Hour, c) {
+		at = append(at, c.Now().Sub(start))
+	}
+	assert.Equal(t, []time.Duration{0, 15 * time.Minute, 30 * time.Minute, 45 * time.Minute, time.Hour}, at)
+
+	start = c.Now()
+	assertSequenceMatch(t, Spread(NewSeq(1), time.Hour, c), []int{1})
+	assertSequenceMatch(t, Spread(NewSeq[int](), time.Hour, c), []int{})
+	assert.Equal(t, start, c.Now())
+}
+
+func TestReplayTimed(t *testing.T) {
+	c := ittest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	recorded := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	trace := NewSeq(0, 10, 10, 40)
+	ts := func(sec int) time.Time { return recorded.Add(time.Duration(sec) * time.Second) }
+
+	start := c.Now()
+	var at []time.Duration
+	for range ReplayTimed(trace, ts, 0.5, c) {
+		at = append(at, c.Now().Sub(start))
+	}
+	assert.Equal(t, []time.Duration{0, 20 * time.Second, 20 * time.Second, 80 * time.Second}, at)
+
+	// a speed of zero replays without waiting
+	start = c.Now()
+	assertSequenceMatch(t, ReplayTimed(trace, ts, 0, c), []int{0, 10, 10, 40})
+	assert.Equal(t, start, c.Now())
+}
+
+func TestRateLimit(t *testing.T) {
+	c := ittest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	start := c.Now()
+	var at []time.Duration
+	for i := range RateLimit(Count(), 2, 3, c) {
+		at = append(at, c.Now().Sub(start))
+		if i == 2 {
+			// idling refills the bucket
+			c.Advance(time.Second)
+		}
+		if len(at) == 7 {
+			break
+		}
+	}
+
+	ms := time.Millisecond
+	assert.Equal(t, []time.Duration{0, 0, 0, 1000 * ms, 1000 * ms, 1500 * ms, 2000 * ms}, at)
+
+	assertSequenceMatch(t, Take(RateLimit(Count(), 0, 0, nil), 3), []int{0, 1, 2})
+}
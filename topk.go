@@ -0,0 +1,141 @@
+package itertools
+
+import (
+	"cmp"
+	"container/heap"
+	"iter"
+	"slices"
+)
+
+type scored[T any, K comparable] struct {
+	value T
+	key   K
+	score float64
+	// seen orders values arriving earlier ahead of later ones with the same score
+	seen int
+}
+
+// before reports whether a ranks above b
+func (a scored[T, K]) before(b scored[T, K]) bool {
+	return a.score > b.score || a.score == b.score && a.seen < b.seen
+}
+
+// scoredHeap is a min-heap of values ordered by score, tracking each key's position
+type scoredHeap[T any, K comparable] struct {
+	items []scored[T, K]
+	index map[K]int
+}
+
+func (h *scoredHeap[T, K]) Len() int           { return len(h.items) }
+func (h *scoredHeap[T, K]) Less(i, j int) bool { return h.items[j].before(h.items[i]) }
+
+func (h *scoredHeap[T, K]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.index[h.items[i].key] = i
+	h.index[h.items[j].key] = j
+}
+
+func (h *scoredHeap[T, K]) Push(x any) {
+	item := x.(scored[T, K])
+	h.index[item.key] = len(h.items)
+	h.items = append(h.items, item)
+}
+
+func (h *scoredHeap[T, K]) Pop() any {
+	item := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	delete(h.index, item.key)
+	return item
+}
+
+// TopKByScore returns the k highest-scoring values of s in descending score order, keeping only the best value for each key
+func TopKByScore[T any, K comparable](s iter.Seq[T], k int, score func(T) float64, key func(T) K) []T {
+	h := &scoredHeap[T, K]{index: make(map[K]int, max(k, 0))}
+
+	var seen int
+	for v := range s {
+		if k <= 0 {
+			break
+		}
+
+		item := scored[T, K]{value: v, key: key(v), score: score(v), seen: seen}
+		seen++
+		if i, ok := h.index[item.key]; ok {
+			if item.score > h.items[i].score {
+				h.items[i] = item
+				heap.Fix(h, i)
+			}
+			continue
+		}
+
+		if h.Len() < k {
+			heap.Push(h, item)
+			continue
+		}
+
+		if item.score > h.items[0].score {
+			heap.Pop(h)
+			heap.Push(h, item)
+		}
+	}
+
+	items := slices.Clone(h.items)
+	slices.SortFunc(items, func(a, b scored[T, K]) int {
+		if a.before(b) {
+			return -1
+		}
+		return 1
+	})
+
+	out := make([]T, len(items))
+	for i, item := range items {
+		out[i] = item.value
+	}
+	return out
+}
+
+// TopK returns the k largest values of s in descending order, using O(k) memory and O(n log k) time
+func TopK[T cmp.Ordered](s iter.Seq[T], k int) []T {
+	return TopKFunc(s, k, cmp.Compare[T])
+}
+
+// BottomK returns the k smallest values of s in ascending order, as [TopK] does for the largest
+func BottomK[T cmp.Ordered](s iter.Seq[T], k int) []T {
+	return TopKFunc(s, k, func(a, b T) int { return cmp.Compare(b, a) })
+}
+
+// TopKFunc returns the k largest values of s according to compare, in descending order. Among equal values the earliest seen are kept
+func TopKFunc[T any](s iter.Seq[T], k int, compare func(a, b T) int) []T {
+	if k <= 0 {
+		return []T{}
+	}
+
+	// the heap's root is the value to evict next: the smallest, and among equals the latest
+	type entry struct {
+		v T
+		i int
+	}
+	ranksBelow := func(a, b entry) bool {
+		if c := compare(a.v, b.v); c != 0 {
+			return c < 0
+		}
+		return a.i > b.i
+	}
+	h := &funcHeap[entry]{less: ranksBelow}
+
+	for i, v := range Enumerate(s) {
+		e := entry{v, i}
+		if h.Len() < k {
+			h.push(e)
+		} else if ranksBelow(h.items[0], e) {
+			h.items[0] = e
+			heap.Fix(h, 0)
+		}
+	}
+
+	out := make([]T, h.Len())
+	for i := len(out) - 1; i >= 0; i-- {
+		out[i] = h.pop().v
+	}
+	return out
+}
@@ -0,0 +1,64 @@
+package itertools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopKByScore(t *testing.T) {
+	type result struct {
+		domain string
+		path   string
+		score  float64
+	}
+	results := []result{
+		{"a.com", "/1", 0.5},
+		{"b.com", "/1", 0.9},
+		{"a.com", "/2", 0.95},
+		{"c.com", "/1", 0.7},
+		{"b.com", "/2", 0.2},
+		{"d.com", "/1", 0.1},
+		{"c.com", "/2", 0.8},
+	}
+	score := func(r result) float64 { return r.score }
+	domain := func(r result) string { return r.domain }
+
+	assert.Equal(t,
+		[]result{{"a.com", "/2", 0.95}, {"b.com", "/1", 0.9}},
+		TopKByScore(FromSlice(results), 2, score, domain),
+	)
+	assert.Equal(t,
+		[]result{{"a.com", "/2", 0.95}, {"b.com", "/1", 0.9}, {"c.com", "/2", 0.8}, {"d.com", "/1", 0.1}},
+		TopKByScore(FromSlice(results), 10, score, domain),
+	)
+	assert.Empty(t, TopKByScore(FromSlice(results), 0, score, domain))
+
+	// an evicted key can return with a better score
+	ident := func(x int) int { return x }
+	assert.Equal(t,
+		[]int{9, 5},
+		TopKByScore(NewSeq(1, 5, 2, 9, 3), 2, func(x int) float64 { return float64(x) }, ident),
+	)
+	assert.Equal(t,
+		[]int{1, 3},
+		TopKByScore(NewSeq(1, 2, 3, 4), 2, func(x int) float64 { return float64(x % 2) }, func(x int) int { return x % 3 }),
+	)
+}
+
+func TestTopK(t *testing.T) {
+	assert.Equal(t, []int{9, 8, 7}, TopK(NewSeq(3, 9, 1, 7, 8, 2), 3))
+	assert.Equal(t, []int{3, 2}, TopK(NewSeq(3, 2), 5))
+	assert.Equal(t, []int{}, TopK(NewSeq(3, 2), 0))
+	assert.Equal(t, []int{999, 998}, TopK(Take(Count(), 1000), 2))
+
+	assert.Equal(t, []int{1, 2, 3}, BottomK(NewSeq(3, 9, 1, 7, 8, 2), 3))
+}
+
+func TestTopKFunc(t *testing.T) {
+	byLen := func(a, b string) int { return len(a) - len(b) }
+	assert.Equal(t,
+		[]string{"ccc", "bb", "dd"},
+		TopKFunc(NewSeq("a", "bb", "ccc", "dd", "ee", "f"), 3, byLen),
+	)
+}
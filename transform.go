@@ -0,0 +1,90 @@
+package itertools
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Transform is a reusable pipeline stage turning a sequence of T into a sequence of U
+type Transform[T any, U any] func(iter.Seq[T]) iter.Seq[U]
+
+// Compose chains same-typed stages into a single [Transform] applying them in order
+func Compose[T any](stages ...Transform[T, T]) Transform[T, T] {
+	return func(s iter.Seq[T]) iter.Seq[T] {
+		for _, stage := range stages {
+			s = stage(s)
+		}
+		return s
+	}
+}
+
+// Then chains two stages into a single [Transform], allowing the element type to change between them
+func Then[T any, U any, V any](first Transform[T, U], second Transform[U, V]) Transform[T, V] {
+	return func(s iter.Seq[T]) iter.Seq[V] {
+		return second(first(s))
+	}
+}
+
+// Stage is a [Transform] with caller-supplied metadata, the building block of a [Pipeline]
+type Stage[T any] struct {
+	Name      string
+	Transform Transform[T, T]
+	// Options describes the parameters the stage was configured with, for reporting by [Pipeline.Explain]
+	Options map[string]any
+	// MaxLen, if positive, is the most values the stage will ever yield
+	MaxLen int
+}
+
+// Pipeline is an ordered chain of stages that can be applied to many sequences and described with Explain
+type Pipeline[T any] struct {
+	stages []Stage[T]
+}
+
+// NewPipeline returns a [Pipeline] running the given stages in order
+func NewPipeline[T any](stages ...Stage[T]) *Pipeline[T] {
+	return &Pipeline[T]{stages: stages}
+}
+
+// Then appends a stage to the pipeline, returning the pipeline for chaining
+func (p *Pipeline[T]) Then(stage Stage[T]) *Pipeline[T] {
+	p.stages = append(p.stages, stage)
+	return p
+}
+
+// Stages returns the stages of the pipeline in order
+func (p *Pipeline[T]) Stages() []Stage[T] {
+	return slices.Clone(p.stages)
+}
+
+// Apply runs s through each stage of the pipeline in turn
+func (p *Pipeline[T]) Apply(s iter.Seq[T]) iter.Seq[T] {
+	for _, stage := range p.stages {
+		s = stage.Transform(s)
+	}
+	return s
+}
+
+// Explain describes the stages of the pipeline, one per line, using only the Name, Options and MaxLen supplied with each [Stage]. It does not inspect the transforms themselves, so it is only as accurate as that metadata
+func (p *Pipeline[T]) Explain() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "pipeline of %d stages", len(p.stages))
+
+	var bound int
+	for i, stage := range p.stages {
+		fmt.Fprintf(&b, "\n  %d. %s", i+1, stage.Name)
+		for _, k := range slices.Sorted(maps.Keys(stage.Options)) {
+			fmt.Fprintf(&b, " %s=%v", k, stage.Options[k])
+		}
+
+		if stage.MaxLen > 0 && (bound == 0 || stage.MaxLen < bound) {
+			bound = stage.MaxLen
+		}
+		if bound > 0 {
+			fmt.Fprintf(&b, " (at most %d values)", bound)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,54 @@
+package itertools
+
+import (
+	"iter"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompose(t *testing.T) {
+	double := func(s iter.Seq[int]) iter.Seq[int] { return Map(func(x int) int { return 2 * x }, s) }
+	firstThree := func(s iter.Seq[int]) iter.Seq[int] { return Take(s, 3) }
+
+	pipeline := Compose(double, firstThree, double)
+	assertSequenceMatch(t, pipeline(Count()), []int{0, 4, 8})
+	assertSequenceMatch(t, pipeline(NewSeq(5, 6)), []int{20, 24})
+
+	assertSequenceMatch(t, Compose[int]()(NewSeq(1, 2)), []int{1, 2})
+}
+
+func TestThen(t *testing.T) {
+	firstThree := func(s iter.Seq[int]) iter.Seq[int] { return Take(s, 3) }
+	format := func(s iter.Seq[int]) iter.Seq[string] { return Map(strconv.Itoa, s) }
+
+	pipeline := Then(firstThree, format)
+	assertSequenceMatch(t, pipeline(Count()), []string{"0", "1", "2"})
+}
+
+func TestPipeline(t *testing.T) {
+	double := Stage[int]{
+		Name:      "double",
+		Transform: func(s iter.Seq[int]) iter.Seq[int] { return Map(func(x int) int { return 2 * x }, s) },
+	}
+	take := func(n int) Stage[int] {
+		return Stage[int]{
+			Name:      "take",
+			Transform: func(s iter.Seq[int]) iter.Seq[int] { return Take(s, n) },
+			Options:   map[string]any{"n": n},
+			MaxLen:    n,
+		}
+	}
+
+	p := NewPipeline(double, take(5)).Then(take(3)).Then(double)
+	assertSequenceMatch(t, p.Apply(Count()), []int{0, 4, 8})
+	assertSequenceMatch(t, p.Apply(NewSeq(7)), []int{28})
+	assert.Len(t, p.Stages(), 4)
+
+	assert.Equal(t, `pipeline of 4 stages
+  1. double
+  2. take n=5 (at most 5 values)
+  3. take n=3 (at most 3 values)
+  4. double (at most 3 values)`, p.Explain())
+}
@@ -0,0 +1,150 @@
+// Package tuples provides fixed-size heterogeneous tuples, the canonical representation for multi-value results of the itertools package
+package tuples
+
+// T2 is a pair of values of possibly different types
+type T2[A any, B any] struct {
+	V1 A
+	V2 B
+}
+
+// Of2 returns a [T2] holding the given values
+func Of2[A any, B any](v1 A, v2 B) T2[A, B] {
+	return T2[A, B]{v1, v2}
+}
+
+// Unpack returns the values of t in order
+func (t T2[A, B]) Unpack() (A, B) {
+	return t.V1, t.V2
+}
+
+// T3 is a triple of values of possibly different types
+type T3[A any, B any, C any] struct {
+	V1 A
+	V2 B
+	V3 C
+}
+
+// Of3 returns a [T3] holding the given values
+func Of3[A any, B any, C any](v1 A, v2 B, v3 C) T3[A, B, C] {
+	return T3[A, B, C]{v1, v2, v3}
+}
+
+// Unpack returns the values of t in order
+func (t T3[A, B, C]) Unpack() (A, B, C) {
+	return t.V1, t.V2, t.V3
+}
+
+// T4 is a 4-tuple of values of possibly different types
+type T4[A any, B any, C any, D any] struct {
+	V1 A
+	V2 B
+	V3 C
+	V4 D
+}
+
+// Of4 returns a [T4] holding the given values
+func Of4[A any, B any, C any, D any](v1 A, v2 B, v3 C, v4 D) T4[A, B, C, D] {
+	return T4[A, B, C, D]{v1, v2, v3, v4}
+}
+
+// Unpack returns the values of t in order
+func (t T4[A, B, C, D]) Unpack() (A, B, C, D) {
+	return t.V1, t.V2, t.V3, t.V4
+}
+
+// T5 is a 5-tuple of values of possibly different types
+type T5[A any, B any, C any, D any, E any] struct {
+	V1 A
+	V2 B
+	V3 C
+	V4 D
+	V5 E
+}
+
+// Of5 returns a [T5] holding the given values
+func Of5[A any, B any, C any, D any, E any](v1 A, v2 B, v3 C, v4 D, v5 E) T5[A, B, C, D, E] {
+	return T5[A, B, C, D, E]{v1, v2, v3, v4, v5}
+}
+
+// Unpack returns the values of t in order
+func (t T5[A, B, C, D, E]) Unpack() (A, B, C, D, E) {
+	return t.V1, t.V2, t.V3, t.V4, t.V5
+}
+
+// T6 is a 6-tuple of values of possibly different types
+type T6[A any, B any, C any, D any, E any, F any] struct {
+	V1 A
+	V2 B
+	V3 C
+	V4 D
+	V5 E
+	V6 F
+}
+
+// Of6 returns a [T6] holding the given values
+func Of6[A any, B any, C any, D any, E any, F any](v1 A, v2 B, v3 C, v4 D, v5 E, v6 F) T6[A, B, C, D, E, F] {
+	return T6[A, B, C, D, E, F]{v1, v2, v3, v4, v5, v6}
+}
+
+// Unpack returns the values of t in order
+func (t T6[A, B, C, D, E, F]) Unpack() (A, B, C, D, E, F) {
+	return t.V1, t.V2, t.V3, t.V4, t.V5, t.V6
+}
+
+// T7 is a 7-tuple of values of possibly different types
+type T7[A any, B any, C any, D any, E any, F any, G any] struct {
+	V1 A
+	V2 B
+	V3 C
+	V4 D
+	V5 E
+	V6 F
+	V7 G
+}
+
+// Of7 returns a [T7] holding the given values
+func Of7[A any, B any, C any, D any, E any, F any, G any](v1 A, v2 B, v3 C, v4 D, v5 E, v6 F, v7 G) T7[A, B, C, D, E, F, G] {
+	return T7[A, B, C, D, E, F, G]{v1, v2, v3, v4, v5, v6, v7}
+}
+
+// Unpack returns the values of t in order
+func (t T7[A, B, C, D, E, F, G]) Unpack() (A, B, C, D, E, F, G) {
+	return t.V1, t.V2, t.V3, t.V4, t.V5, t.V6, t.V7
+}
+
+// T8 is a 8-tuple of values of possibly different types
+type T8[A any, B any, C any, D any, E any, F any, G any, H any] struct {
+	V1 A
+	V2 B
+	V3 C
+	V4 D
+	V5 E
+	V6 F
+	V7 G
+	V8 H
+}
+
+// Of8 returns a [T8] holding the given values
+func Of8[A any, B any, C any, D any, E any, F any, G any, H any](v1 A, v2 B, v3 C, v4 D, v5 E, v6 F, v7 G, v8 H) T8[A, B, C, D, E, F, G, H] {
+	return T8[A, B, C, D, E, F, G, H]{v1, v2, v3, v4, v5, v6, v7, v8}
+}
+
+// Unpack returns the values of t in order
+func (t T8[A, B, C, D, E, F, G, H]) Unpack() (A, B, C, D, E, F, G, H) {
+	return t.V1, t.V2, t.V3, t.V4, t.V5, t.V6, t.V7, t.V8
+}
+
+// MapFirst applies f to the first value of t
+func MapFirst[A any, B any, C any](t T2[A, B], f func(A) C) T2[C, B] {
+	return T2[C, B]{f(t.V1), t.V2}
+}
+
+// MapSecond applies f to the second value of t
+func MapSecond[A any, B any, C any](t T2[A, B], f func(B) C) T2[A, C] {
+	return T2[A, C]{t.V1, f(t.V2)}
+}
+
+// Swap exchanges the values of t
+func Swap[A any, B any](t T2[A, B]) T2[B, A] {
+	return T2[B, A]{t.V2, t.V1}
+}
@@ -0,0 +1,30 @@
+package tuples
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnpack(t *testing.T) {
+	a, b := Of2("x", 1).Unpack()
+	assert.Equal(t, "x", a)
+	assert.Equal(t, 1, b)
+
+	c, d, e := Of3(1, "y", true).Unpack()
+	assert.Equal(t, 1, c)
+	assert.Equal(t, "y", d)
+	assert.True(t, e)
+
+	v1, _, _, _, _, _, _, v8 := Of8(1, 2, 3, 4, 5, 6, 7, "eight").Unpack()
+	assert.Equal(t, 1, v1)
+	assert.Equal(t, "eight", v8)
+}
+
+func TestMap(t *testing.T) {
+	p := Of2(3, "x")
+	assert.Equal(t, Of2("3", "x"), MapFirst(p, strconv.Itoa))
+	assert.Equal(t, Of2(3, 1), MapSecond(p, func(s string) int { return len(s) }))
+	assert.Equal(t, Of2("x", 3), Swap(p))
+}
@@ -0,0 +1,140 @@
+package itertools
+
+import (
+	"cmp"
+	"iter"
+	"time"
+
+	"github.com/astonm/go-itertools/tuples"
+)
+
+// WindowJoin pairs each element of a with each element of b whose timestamps are within window of each other. Both inputs must be ordered by timestamp; only elements still inside the window are buffered. Pairs are emitted as soon as their later element is read
+func WindowJoin[T any](a, b iter.Seq[T], tsA, tsB func(T) time.Time, window time.Duration) iter.Seq[tuples.T2[T, T]] {
+	return func(yield func(tuples.T2[T, T]) bool) {
+		nextA, stopA := iter.Pull(a)
+		nextB, stopB := iter.Pull(b)
+
+		defer stopA()
+		defer stopB()
+
+		var bufA, bufB []T
+		evict := func(buf []T, ts func(T) time.Time, now time.Time) []T {
+			i := 0
+			for i < len(buf) && now.Sub(ts(buf[i])) > window {
+				i++
+			}
+			return buf[i:]
+		}
+
+		va, okA := nextA()
+		vb, okB := nextB()
+		for okA || okB {
+			if okA && (!okB || !tsB(vb).Before(tsA(va))) {
+				now := tsA(va)
+				bufB = evict(bufB, tsB, now)
+				for _, other := range bufB {
+					if !yield(tuples.Of2(va, other)) {
+						return
+					}
+				}
+				bufA = append(evict(bufA, tsA, now), va)
+				va, okA = nextA()
+				continue
+			}
+
+			now := tsB(vb)
+			bufA = evict(bufA, tsA, now)
+			for _, other := range bufA {
+				if !yield(tuples.Of2(other, vb)) {
+					return
+				}
+			}
+			bufB = append(evict(bufB, tsB, now), vb)
+			vb, okB = nextB()
+		}
+	}
+}
+
+// Reorder buffers up to maxLag values of s in a heap, emitting them in ascending key order. Input that is out of order by no more than maxLag positions comes out fully sorted
+func Reorder[T any, K cmp.Ordered](s iter.Seq[T], key func(T) K, maxLag int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		h := &funcHeap[T]{less: func(a, b T) bool { return key(a) < key(b) }}
+		for v := range s {
+			h.push(v)
+			if h.Len() > maxLag {
+				if !yield(h.pop()) {
+					return
+				}
+			}
+		}
+
+		for h.Len() > 0 {
+			if !yield(h.pop()) {
+				return
+			}
+		}
+	}
+}
+
+// Sessions groups the time-ordered sequence s into sessions, starting a new session whenever the gap between consecutive timestamps exceeds gap
+func Sessions[T any](s iter.Seq[T], ts func(T) time.Time, gap time.Duration) iter.Seq[[]T] {
+	return ChunkWhile(s, func(prev, next T) bool {
+		return ts(next).Sub(ts(prev)) <= gap
+	})
+}
+
+// TumblingWindows yields the start time and contents of each non-empty, non-overlapping window of the given width in s. It panics if width is not positive
+func TumblingWindows[T any](s iter.Seq[T], ts func(T) time.Time, width time.Duration) iter.Seq2[time.Time, []T] {
+	return HoppingWindows(s, ts, width, width)
+}
+
+type timeWindow[T any] struct {
+	start time.Time
+	items []T
+}
+
+// HoppingWindows yields the start time and contents of each non-empty window of the given width starting at every multiple of hop in s. It panics if width or hop is not positive
+func HoppingWindows[T any](s iter.Seq[T], ts func(T) time.Time, width, hop time.Duration) iter.Seq2[time.Time, []T] {
+	if width <= 0 || hop <= 0 {
+		panic("itertools: non-positive window width or hop")
+	}
+
+	return func(yield func(time.Time, []T) bool) {
+		var open []timeWindow[T]
+
+		for v := range s {
+			t := ts(v)
+
+			closed := 0
+			for closed < len(open) && !open[closed].start.Add(width).After(t) {
+				if !yield(open[closed].start, open[closed].items) {
+					return
+				}
+				closed++
+			}
+			open = open[closed:]
+
+			first := t.Add(-width).Truncate(hop)
+			if !first.After(t.Add(-width)) {
+				first = first.Add(hop)
+			}
+
+			i := 0
+			for start := first; !start.After(t); start = start.Add(hop) {
+				for i < len(open) && open[i].start.Before(start) {
+					i++
+				}
+				if i == len(open) {
+					open = append(open, timeWindow[T]{start: start})
+				}
+				open[i].items = append(open[i].items, v)
+			}
+		}
+
+		for _, w := range open {
+			if !yield(w.start, w.items) {
+				return
+			}
+		}
+	}
+}
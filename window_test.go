@@ -0,0 +1,108 @@
+package itertools
+
+import (
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/astonm/go-itertools/tuples"
+	"github.com/stretchr/testify/assert"
+)
+
+type timed struct {
+	name string
+	at   int
+}
+
+var timedBase = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func timedAt(e timed) time.Time { return timedBase.Add(time.Duration(e.at) * time.Second) }
+
+func TestWindowJoin(t *testing.T) {
+	a := NewSeq(timed{"a0", 0}, timed{"a5", 5}, timed{"a20", 20})
+	b := NewSeq(timed{"b1", 1}, timed{"b3", 3}, timed{"b9", 9}, timed{"b30", 30})
+
+	assertSequenceMatch(t,
+		WindowJoin(a, b, timedAt, timedAt, 2*time.Second),
+		[]tuples.T2[timed, timed]{
+			tuples.Of2(timed{"a0", 0}, timed{"b1", 1}),
+			tuples.Of2(timed{"a5", 5}, timed{"b3", 3}),
+		},
+	)
+
+	assertSequenceMatch(t,
+		WindowJoin(a, b, timedAt, timedAt, 4*time.Second),
+		[]tuples.T2[timed, timed]{
+			tuples.Of2(timed{"a0", 0}, timed{"b1", 1}),
+			tuples.Of2(timed{"a0", 0}, timed{"b3", 3}),
+			tuples.Of2(timed{"a5", 5}, timed{"b1", 1}),
+			tuples.Of2(timed{"a5", 5}, timed{"b3", 3}),
+			tuples.Of2(timed{"a5", 5}, timed{"b9", 9}),
+		},
+	)
+}
+
+func TestReorder(t *testing.T) {
+	identity := func(x int) int { return x }
+
+	assertSequenceMatch(t,
+		Reorder(NewSeq(2, 1, 3, 5, 4, 7, 6, 8), identity, 1),
+		[]int{1, 2, 3, 4, 5, 6, 7, 8},
+	)
+
+	assertSequenceMatch(t,
+		Reorder(NewSeq(3, 1, 2, 6, 4, 5), identity, 2),
+		[]int{1, 2, 3, 4, 5, 6},
+	)
+
+	assertSequenceMatch(t,
+		Reorder(NewSeq(3, 2, 1), identity, 1),
+		[]int{2, 1, 3},
+	)
+}
+
+func TestSessions(t *testing.T) {
+	events := NewSeq(timed{"a", 0}, timed{"b", 2}, timed{"c", 5}, timed{"d", 20}, timed{"e", 21}, timed{"f", 40})
+
+	assertSequenceMatch(t,
+		Sessions(events, timedAt, 3*time.Second),
+		[][]timed{
+			{{"a", 0}, {"b", 2}, {"c", 5}},
+			{{"d", 20}, {"e", 21}},
+			{{"f", 40}},
+		},
+	)
+}
+
+func collectWindows(s iter.Seq2[time.Time, []timed]) ([]int, [][]string) {
+	var starts []int
+	var names [][]string
+	for start, items := range s {
+		starts = append(starts, int(start.Sub(timedBase)/time.Second))
+		names = append(names, toSlice(Map(func(e timed) string { return e.name }, FromSlice(items))))
+	}
+	return starts, names
+}
+
+func TestTumblingWindows(t *testing.T) {
+	events := NewSeq(timed{"a", 0}, timed{"b", 4}, timed{"c", 5}, timed{"d", 9}, timed{"e", 21})
+
+	starts, names := collectWindows(TumblingWindows(events, timedAt, 5*time.Second))
+	assert.Equal(t, []int{0, 5, 20}, starts)
+	assert.Equal(t, [][]string{{"a", "b"}, {"c", "d"}, {"e"}}, names)
+
+	assert.Panics(t, func() { TumblingWindows(events, timedAt, 0) })
+	assert.Panics(t, func() { TumblingWindows(events, timedAt, -time.Second) })
+}
+
+func TestHoppingWindows(t *testing.T) {
+	events := NewSeq(timed{"a", 0}, timed{"b", 4}, timed{"c", 5}, timed{"d", 9}, timed{"e", 21})
+
+	starts, names := collectWindows(HoppingWindows(events, timedAt, 10*time.Second, 5*time.Second))
+	assert.Equal(t, []int{-5, 0, 5, 15, 20}, starts)
+	assert.Equal(t, [][]string{{"a", "b"}, {"a", "b", "c", "d"}, {"c", "d"}, {"e"}, {"e"}}, names)
+
+	assert.Panics(t, func() { HoppingWindows(events, timedAt, 10*time.Second, 0) })
+	assert.Panics(t, func() { HoppingWindows(events, timedAt, 0, 5*time.Second) })
+	assert.Panics(t, func() { HoppingWindows(events, timedAt, 10*time.Second, -time.Second) })
+}